@@ -54,13 +54,13 @@ func (l *ArrayList) checkResize(cnts ...int) {
 	if len(cnts) > 0 {
 		cnt = cnts[0]
 	}
-	//到8就不能再缩了
-	if l._capacity > 8 || cnt >= 8 {
+	//容量已满时总是扩容；到8就不能再缩了
+	if l._size == l._capacity || l._capacity > 8 || cnt >= 8 {
 		new_capacity := cnt
 		if cnt <= 0 {
 			if l._size == l._capacity {
 				new_capacity = l._capacity * 2
-			} else if l._size < l._capacity/4 {
+			} else if l._capacity > 8 && l._size < l._capacity/4 {
 				new_capacity = l._capacity / 2
 			}
 		}
@@ -74,3 +74,28 @@ func Unlimit() int {
 func Is_Unlimit(t int) bool {
 	return t >= INT_MAX/3
 }
+
+/*!
+* @description:饱和的距离加法，用于最短路径的松弛操作
+* @param d: 距离，Is_Unlimit(d)时为正无穷
+* @param w: 权重或者距离，Is_Unlimit(w)时为正无穷
+* @return : d+w，结果不会溢出：
+*
+* - d或w为正无穷，或者d+w不小于INT_MAX/3时，返回Unlimit()
+* - d+w不大于-INT_MAX/3(例如存在权重为负值的环路时反复松弛)时，返回-Unlimit()
+*
+* 直接计算d+w时，正无穷加上一个负的权重可能变成"有限"的值，两个很大的数相加也可能溢出为负数。
+ */
+func AddDistance(d, w int) int {
+	if Is_Unlimit(d) || Is_Unlimit(w) {
+		return Unlimit()
+	}
+	sum := d + w
+	if w < 0 && sum > d || sum <= -INT_MAX/3 { //向下溢出或者为负无穷
+		return -Unlimit()
+	}
+	if Is_Unlimit(sum) {
+		return Unlimit()
+	}
+	return sum
+}
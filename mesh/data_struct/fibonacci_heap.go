@@ -288,6 +288,7 @@ func (heap *FibonacciHeap) Insert(x *FibonacciNode, consolidate_now ...bool) err
 		heap.roots.Append(x)
 		x.Left = heap.minNode.Left
 		x.Right = heap.minNode
+		heap.minNode.Left.Right = x
 		heap.minNode.Left = x
 		if heap.Compare(x.Key, heap.minNode.Key) > 0 {
 			heap.minNode = x
@@ -338,7 +339,8 @@ func (heap *FibonacciHeap) _getRootKeys(bysize ...bool) string {
  * @return:
 */
 func (heap *FibonacciHeap) consolidate() {
-	degrees := make([]*FibonacciNode, heap.n+1)
+	//根节点的度数为O(lgn)，数组按需扩展
+	degrees := []*FibonacciNode{}
 
 	tmpList := NewArrayList(heap.roots.Capacity(), heap.Compare)
 	var end *FibonacciNode = nil
@@ -352,13 +354,15 @@ func (heap *FibonacciHeap) consolidate() {
 	}
 
 	//千万要注意这里的循环结束方式，链表的结构随时在变化，所以需要可靠的中止条件
-	for !tmpList.Empty() {
-		_, start := tmpList.DeleteAt(0)
-		x, ok := start.(*FibonacciNode)
+	for i := 0; i < tmpList.Size(); i++ {
+		x, ok := tmpList.ItemAt(i).(*FibonacciNode)
 		if !ok {
 			break
 		}
 		d := x._Degree
+		for len(degrees) <= d+1 {
+			degrees = append(degrees, nil)
+		}
 
 		for degrees[d] != nil {
 			y := degrees[d]
@@ -372,6 +376,9 @@ func (heap *FibonacciHeap) consolidate() {
 
 			degrees[d] = nil
 			d++
+			if len(degrees) <= d+1 {
+				degrees = append(degrees, nil)
+			}
 		}
 
 		degrees[d] = x
@@ -379,7 +386,7 @@ func (heap *FibonacciHeap) consolidate() {
 
 	heap.minNode = nil
 
-	for i := 0; i < heap.n; i++ {
+	for i := 0; i < len(degrees); i++ {
 		if degrees[i] != nil {
 			if heap.minNode == nil {
 				//create a root list for heap containing just A[i]
@@ -406,7 +413,7 @@ func (heap *FibonacciHeap) link(y, x *FibonacciNode) {
 	y.Left.Right = y.Right
 	y.Right.Left = y.Left
 
-	heap.roots.Delete(y)
+	//consolidate结束时会根据环形链表重建roots，这里不需要逐个从roots中删除
 	y.Parent = x
 	x.Children.Append(y)
 	x._Degree++
@@ -422,32 +429,26 @@ ok
  * @return: 返回minNode节点
 */
 func (heap *FibonacciHeap) ExtractMin() *FibonacciNode {
-	fmt.Println("###### roots:", heap._getRootKeys(false), " ----- ", heap._getRootKeys(true))
 	z := heap.minNode
 	if z != nil {
-		lastLeft := z.Left
-		lastRight := z.Right
-		heap.roots.Delete(z)
-
+		//z的孩子逐个插入到z的左边，成为根节点
 		size := z.Children.Size()
 		for i := 0; i < size; i++ {
 			child := z.ChildAt(i)
-			heap.roots.Append(child)
 			child.Parent = nil
-			child.Left = lastLeft
-
-			if i == 0 {
-				lastLeft.Right = child
-				child.Right = z.ChildAt(i + 1)
-			} else if i < size-1 {
-				child.Right = z.ChildAt(i + 1)
-			} else {
-				child.Right = lastRight
-				lastRight.Left = child
-			}
-			lastLeft = child
-			child.updateDegree()
+			child.Left = z.Left
+			child.Right = z
+			z.Left.Right = child
+			z.Left = child
+			heap.roots.Append(child)
 		}
+		z.Children.Clear()
+		z._Degree = 0
+
+		//从环形链表中摘除z
+		z.Left.Right = z.Right
+		z.Right.Left = z.Left
+		heap.roots.Delete(z)
 
 		if z == z.Right {
 			heap.minNode = nil
@@ -495,14 +496,16 @@ func (heap *FibonacciHeap) DecreaseKey(x *FibonacciNode, key interface{}) error
 */
 func (heap *FibonacciHeap) cut(x, y *FibonacciNode) {
 	y.Children.Delete(x)
+	y.updateDegree()
+	y.updateLinkList()
 	heap.roots.Append(x)
 	x.Parent = nil
 	x.Mark = false
 
+	x.Left = heap.minNode.Left
+	x.Right = heap.minNode
 	heap.minNode.Left.Right = x
-	x.Left = heap.minNode.Left.Right
 	heap.minNode.Left = x
-	x.Right = heap.minNode
 
 }
 
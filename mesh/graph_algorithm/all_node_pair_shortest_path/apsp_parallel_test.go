@@ -0,0 +1,113 @@
+/*
+ * @Description: 并行Floyd-Warshall与Johnson算法测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 19:35:12
+ * @LastEditTime: 2026-10-19 19:35:12
+ * @LastEditors:
+ */
+package AllNodePairShortestPath
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description:随机生成的有向图，边的权重可以为负，但不存在权重为负值的环路：w(u,v)=c(u,v)+p(u)-p(v)，其中c(u,v)>=0
+ */
+func randomPotentialGraph(num int, seed int64) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(seed))
+	potential := make([]int, num)
+	for i := range potential {
+		potential[i] = r.Intn(50)
+	}
+	graph := NewGraph(Unlimit(), num, creator)
+	for i := 0; i < num; i++ {
+		graph.AddVertex(0)
+	}
+	for i := 0; i < num; i++ {
+		for j := 0; j < num; j++ {
+			if i != j && r.Intn(4) == 0 {
+				graph.AddEdge(NewTuple(i, j, r.Intn(20)+potential[i]-potential[j]))
+			}
+		}
+	}
+	return graph
+}
+
+func TestParallelAllPairs(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		graph := randomPotentialGraph(60, seed)
+
+		D, P, _ := NewFloydWarshallSP().ShortestPath(graph)
+		johnson, _ := NewJohnsonSP().ShortestPath(graph)
+		for _, workers := range []int{2, 3, 8, 100} {
+			parallel_fw := &FloydWarshallSP{Workers: workers}
+			pD, pP, _ := parallel_fw.ShortestPath(graph)
+			EXPECT_EQ(pD, D, t)
+			EXPECT_EQ(pP, P, t)
+
+			parallel_johnson := &JohnsonSP{Workers: workers}
+			pJ, err := parallel_johnson.ShortestPath(graph)
+			EXPECT_EQ(err, nil, t)
+			EXPECT_EQ(pJ, johnson, t)
+		}
+		for i := range D {
+			for j := range D[i] {
+				if !Is_Unlimit(D[i][j]) {
+					EXPECT_EQ(johnson[i][j], D[i][j], t)
+				}
+			}
+		}
+	}
+
+	//**********  存在权重为负值的环路时，Floyd-Warshall并行计算的结果也与串行计算相同  ***************
+	graph := randomPotentialGraph(30, 9)
+	graph.AddEdge(NewTuple(0, 1, -1000))
+	graph.AddEdge(NewTuple(1, 0, -1000))
+	D, P, _ := NewFloydWarshallSP().ShortestPath(graph)
+	pD, pP, _ := (&FloydWarshallSP{Workers: 4}).ShortestPath(graph)
+	EXPECT_EQ(pD, D, t)
+	EXPECT_EQ(pP, P, t)
+}
+
+func benchmarkFloydWarshall(b *testing.B, workers int) {
+	graph := randomPotentialGraph(300, 1)
+	sp := &FloydWarshallSP{Workers: workers}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sp.ShortestPath(graph)
+	}
+}
+
+func BenchmarkFloydWarshall(b *testing.B) {
+	benchmarkFloydWarshall(b, 1)
+}
+
+func BenchmarkFloydWarshallParallel(b *testing.B) {
+	benchmarkFloydWarshall(b, 4)
+}
+
+func benchmarkJohnson(b *testing.B, workers int) {
+	graph := randomPotentialGraph(100, 1)
+	sp := &JohnsonSP{Workers: workers}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sp.ShortestPath(graph)
+	}
+}
+
+func BenchmarkJohnson(b *testing.B) {
+	benchmarkJohnson(b, 1)
+}
+
+func BenchmarkJohnsonParallel(b *testing.B) {
+	benchmarkJohnson(b, 4)
+}
@@ -0,0 +1,286 @@
+/*
+ * @Description: 所有结点对最短路径的结果：距离查询、路径重建以及二进制序列化
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 19:48:26
+ * @LastEditTime: 2026-10-19 19:48:26
+ * @LastEditors:
+ *
+ *
+ * APSPResult保存权重矩阵D与前驱矩阵P，p_u_v为从结点u到v的一条最短路径上v的前驱结点。从v出发沿着p_u_v、p_u_(p_u_v)...回溯到u，
+ * 就得到了u到v的最短路径，时间复杂度为路径的长度。
+ *
+ * FloydWarshallSP直接给出了前驱矩阵；JohnsonSP的前驱矩阵来自每次Dijkstra算法得到的最短路径树；MatrixSP只计算权重矩阵，
+ * 前驱矩阵由"紧"边构造：对每个源点u，从u出发只沿着满足 d_u_x+w(x,v)=d_u_v 的边(x,v)做广度优先搜索，得到的搜索树就是一棵最短路径树。
+ *
+ * ## 二进制格式
+ *
+ * 依次为：4字节的魔数"APSP"，1字节的版本号，结点数n(uvarint)，然后按行存放n*n个元素。每个元素为 p_u_v+1(uvarint)，
+ * 如果u=v或者v从u可达，后面再跟着 d_u_v(varint)。不可达的结点对只占一个字节。
+ */
+package AllNodePairShortestPath
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+const (
+	APSP_MAGIC   = "APSP"
+	APSP_VERSION = 1
+)
+
+type APSPResult struct {
+	dist [][]int
+	pred [][]int
+}
+
+/**
+ * @description: 由权重矩阵和前驱矩阵创建结果
+ * @param D: n*n的权重矩阵
+ * @param P: n*n的前驱矩阵，不存在前驱结点时为-1
+ */
+func NewAPSPResult(D, P [][]int) (*APSPResult, error) {
+	if len(D) != len(P) {
+		return nil, errors.New("NewAPSPResult error: D and P must have the same size!")
+	}
+	for i := range D {
+		if len(D[i]) != len(D) || len(P[i]) != len(D) {
+			return nil, errors.New("NewAPSPResult error: D and P must be n*n matrixes!")
+		}
+	}
+	return &APSPResult{dist: D, pred: P}, nil
+}
+
+// 结点的数目
+func (a *APSPResult) N() int {
+	return len(a.dist)
+}
+
+func (a *APSPResult) reachable(u, v int) bool {
+	return u == v || a.pred[u][v] >= 0
+}
+
+/**
+ * @description: 结点u到v的最短路径权重
+ * @return: 最短路径权重；v从u不可达时返回*UnreachableError
+ */
+func (a *APSPResult) Dist(u, v int) (int, error) {
+	if u < 0 || u >= a.N() || v < 0 || v >= a.N() {
+		return 0, errors.New("Dist error: u and v must belongs [0,N)!")
+	}
+	if !a.reachable(u, v) {
+		return 0, &UnreachableError{Source: u, Target: v}
+	}
+	return a.dist[u][v], nil
+}
+
+/**
+ * @description: 结点u到v的最短路径
+ * @return: 路径上的结点`id`，从u到v；v从u不可达或者参数无效时返回nil
+ */
+func (a *APSPResult) Path(u, v int) []int {
+	if u < 0 || u >= a.N() || v < 0 || v >= a.N() || !a.reachable(u, v) {
+		return nil
+	}
+	path := []int{v}
+	for x := v; x != u; {
+		x = a.pred[u][x]
+		if x < 0 || len(path) > a.N() { //前驱矩阵不构成最短路径树(例如存在权重为负值的环路)
+			return nil
+		}
+		path = append(path, x)
+	}
+	Revert(path)
+	return path
+}
+
+/**
+ * @description: 以二进制格式写入w
+ * @return: 写入的字节数；error
+ */
+func (a *APSPResult) WriteTo(w io.Writer) (int64, error) {
+	writer := bufio.NewWriter(w)
+	var count int64
+	buf := make([]byte, binary.MaxVarintLen64)
+	put := func(b []byte) error {
+		n, err := writer.Write(b)
+		count += int64(n)
+		return err
+	}
+
+	if err := put(append([]byte(APSP_MAGIC), APSP_VERSION)); err != nil {
+		return count, err
+	}
+	if err := put(buf[:binary.PutUvarint(buf, uint64(a.N()))]); err != nil {
+		return count, err
+	}
+	for u := range a.dist {
+		for v := range a.dist[u] {
+			if err := put(buf[:binary.PutUvarint(buf, uint64(a.pred[u][v]+1))]); err != nil {
+				return count, err
+			}
+			if a.reachable(u, v) {
+				if err := put(buf[:binary.PutVarint(buf, int64(a.dist[u][v]))]); err != nil {
+					return count, err
+				}
+			}
+		}
+	}
+	return count, writer.Flush()
+}
+
+/**
+ * @description: 从r中读取WriteTo写入的结果
+ */
+func ReadAPSPResult(r io.Reader) (*APSPResult, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(APSP_MAGIC)+1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(APSP_MAGIC)]) != APSP_MAGIC || header[len(APSP_MAGIC)] != APSP_VERSION {
+		return nil, errors.New("ReadAPSPResult error: invalid header!")
+	}
+	n, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if n > 1<<20 {
+		return nil, errors.New("ReadAPSPResult error: too many vertexes!")
+	}
+
+	//矩阵按照已读取的元素逐步增长，而不是按照头部的n预先分配：每个元素至少占一个字节，
+	//因此截断或者伪造的输入占用的内存不超过实际读取字节数的常数倍
+	num := int(n)
+	unlimit := Unlimit()
+	D := [][]int{}
+	P := [][]int{}
+	for u := 0; u < num; u++ {
+		dist_row, pred_row := []int{}, []int{}
+		for v := 0; v < num; v++ {
+			p, err := binary.ReadUvarint(reader)
+			if err != nil {
+				return nil, err
+			}
+			if p > n {
+				return nil, errors.New("ReadAPSPResult error: invalid predecessor!")
+			}
+			pred_row = append(pred_row, int(p)-1)
+			dist_row = append(dist_row, unlimit)
+			if u == v || p > 0 {
+				d, err := binary.ReadVarint(reader)
+				if err != nil {
+					return nil, err
+				}
+				dist_row[v] = int(d)
+			}
+		}
+		D = append(D, dist_row)
+		P = append(P, pred_row)
+	}
+	return &APSPResult{dist: D, pred: P}, nil
+}
+
+/**
+ * @description: 以二进制格式保存到文件
+ */
+func (a *APSPResult) Save(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := a.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+/**
+ * @description: 从文件中读取Save保存的结果
+ */
+func LoadAPSPResult(filename string) (*APSPResult, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadAPSPResult(file)
+}
+
+/**
+ * @description: floyd_warshall算法，返回APSPResult
+ */
+func (a *FloydWarshallSP) ShortestPathResult(graph *Graph) (*APSPResult, error) {
+	D, P, err := a.ShortestPath(graph)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPSPResult(D, P)
+}
+
+/**
+ * @description: johnson算法，返回APSPResult。存在权重为负值的环路时返回*NegativeCycleError
+ */
+func (a *JohnsonSP) ShortestPathResult(graph *Graph) (*APSPResult, error) {
+	D, P, err := a.shortestPath(graph)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPSPResult(D, P)
+}
+
+/**
+ * @description: 矩阵乘法复平方算法，返回APSPResult。要求图中不存在权重为负值的环路
+ */
+func (a *MatrixSP) ShortestPathResult(graph *Graph) (*APSPResult, error) {
+	D, err := a.ShortestPathFast(graph)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPSPResult(D, predecessorMatrix(graph, D))
+}
+
+/**
+ * @description: 由权重矩阵构造前驱矩阵：对每个源点u，沿着紧边做广度优先搜索
+ *
+ * 性能：时间复杂度O(VE)
+ */
+func predecessorMatrix(graph *Graph, D [][]int) [][]int {
+	num := len(D)
+	P := NewMatrix(num, -1)
+	adj := make([][]*Tuple, num)
+	for u := 0; u < num; u++ {
+		if graph.Vertexes[u] != nil {
+			adj[u], _ = graph.VertexEdgeTuples(u)
+		}
+	}
+	for u := 0; u < num; u++ {
+		if graph.Vertexes[u] == nil {
+			continue
+		}
+		queue := []int{u}
+		for len(queue) > 0 {
+			x := queue[0]
+			queue = queue[1:]
+			for _, edge := range adj[x] {
+				v := edge.Second
+				if v == u || P[u][v] >= 0 || Is_Unlimit(D[u][v]) {
+					continue
+				}
+				if AddDistance(D[u][x], edge.Third) == D[u][v] {
+					P[u][v] = x
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	return P
+}
@@ -0,0 +1,142 @@
+/*
+ * @Description: 所有结点对最短路径的结果以及二进制序列化测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 19:48:26
+ * @LastEditTime: 2026-10-19 19:48:26
+ * @LastEditors:
+ */
+package AllNodePairShortestPath
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+/**
+ * @description: APSPResult的查询、二进制序列化，以及由权重矩阵构造前驱矩阵
+ */
+func TestAPSPResult(t *testing.T) {
+	NUM := 40
+	graph := randomPotentialGraph(NUM, 5)
+	results := []*APSPResult{}
+	fw, _ := NewFloydWarshallSP().ShortestPathResult(graph)
+	johnson, _ := NewJohnsonSP().ShortestPathResult(graph)
+	parallel_johnson, _ := (&JohnsonSP{Workers: 3}).ShortestPathResult(graph)
+	matrix, _ := NewMatrixSP().ShortestPathResult(graph)
+	results = append(results, fw, johnson, parallel_johnson, matrix)
+
+	file := filepath.Join(t.TempDir(), "apsp.bin")
+	EXPECT_EQ(fw.Save(file), nil, t)
+	loaded, err := LoadAPSPResult(file)
+	EXPECT_EQ(err, nil, t)
+	results = append(results, loaded)
+	EXPECT_EQ(*loaded, *fw, t)
+
+	for u := 0; u < NUM; u++ {
+		for v := 0; v < NUM; v++ {
+			expect, expect_err := fw.Dist(u, v)
+			for _, result := range results {
+				d, err := result.Dist(u, v)
+				EXPECT_EQ(d, expect, t)
+				EXPECT_EQ(err, expect_err, t)
+				path := result.Path(u, v)
+				if err != nil {
+					EXPECT_EQ(path, []int(nil), t)
+					continue
+				}
+				EXPECT_EQ(path[0], u, t)
+				EXPECT_EQ(path[len(path)-1], v, t)
+				sum := 0
+				for i := 1; i < len(path); i++ {
+					w, _ := graph.Weight(path[i-1], path[i])
+					sum += w
+				}
+				EXPECT_EQ(sum, d, t)
+			}
+		}
+	}
+	//**********  不可达的结点对  ***************
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	_graph := NewGraph(Unlimit(), 3, creator)
+	for i := 0; i < 3; i++ {
+		_graph.AddVertex(0)
+	}
+	_graph.AddEdge(NewTuple(0, 1, -2))
+	for _, sp := range []interface {
+		ShortestPathResult(graph *Graph) (*APSPResult, error)
+	}{NewFloydWarshallSP(), NewJohnsonSP(), NewMatrixSP()} {
+		result, _ := sp.ShortestPathResult(_graph)
+		d, _ := result.Dist(0, 1)
+		EXPECT_EQ(d, -2, t)
+		EXPECT_EQ(result.Path(0, 1), []int{0, 1}, t)
+		_, err = result.Dist(1, 0)
+		EXPECT_EQ(err, error(&UnreachableError{Source: 1, Target: 0}), t)
+		EXPECT_EQ(result.Path(0, 2), []int(nil), t)
+	}
+
+	_, err = ReadAPSPResult(bytes.NewReader([]byte("APSQ\x01\x00")))
+	EXPECT_EQ(err != nil, true, t)
+
+	//**********  截断的数据流：任何一个真前缀都不能被成功读取  ***************
+	var buf bytes.Buffer
+	_, err = fw.WriteTo(&buf)
+	EXPECT_EQ(err, nil, t)
+	data := buf.Bytes()
+	roundtrip, err := ReadAPSPResult(bytes.NewReader(data))
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(*roundtrip, *fw, t)
+	for size := 0; size < len(data); size++ {
+		_, err = ReadAPSPResult(bytes.NewReader(data[:size]))
+		EXPECT_EQ(err != nil, true, t)
+	}
+
+	//**********  前驱超出范围：n=2，第一个前驱编码为3，即前驱为结点2  ***************
+	header := append([]byte(APSP_MAGIC), APSP_VERSION)
+	_, err = ReadAPSPResult(bytes.NewReader(append(header, 2, 3, 0)))
+	EXPECT_EQ(err != nil, true, t)
+	//每个结点对依次为前驱+1(uvarint)以及可达时的权重(varint，-3编码为5)
+	valid, err := ReadAPSPResult(bytes.NewReader(append(header, 2, 0, 0, 1, 5, 0, 0, 0)))
+	EXPECT_EQ(err, nil, t)
+	d, err := valid.Dist(0, 1)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(d, -3, t)
+	EXPECT_EQ(valid.Path(0, 1), []int{0, 1}, t)
+
+	//**********  头部声明了很大的n但是随后就结束：返回error，且不按照n*n预先分配内存  ***************
+	varint := make([]byte, binary.MaxVarintLen64)
+	for _, num := range []uint64{1 << 15, 1 << 20} {
+		large := append(append([]byte(APSP_MAGIC), APSP_VERSION), varint[:binary.PutUvarint(varint, num)]...)
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err = ReadAPSPResult(bytes.NewReader(large))
+		EXPECT_EQ(err != nil, true, t)
+		_, err = ReadAPSPResult(bytes.NewReader(append(large, 0, 0, 1, 5, 0)))
+		EXPECT_EQ(err != nil, true, t)
+		runtime.ReadMemStats(&after)
+		EXPECT_EQ(after.TotalAlloc-before.TotalAlloc < 1<<20, true, t)
+	}
+
+	//**********  predecessorMatrix得到的前驱矩阵与Floyd-Warshall的最短路径权重一致  ***************
+	D, _, _ := NewFloydWarshallSP().ShortestPath(graph)
+	P := predecessorMatrix(graph, D)
+	for u := 0; u < NUM; u++ {
+		for v := 0; v < NUM; v++ {
+			if u == v || Is_Unlimit(D[u][v]) {
+				EXPECT_EQ(P[u][v], -1, t)
+				continue
+			}
+			w, _ := graph.Weight(P[u][v], v)
+			EXPECT_EQ(D[u][P[u][v]]+w, D[u][v], t)
+		}
+	}
+}
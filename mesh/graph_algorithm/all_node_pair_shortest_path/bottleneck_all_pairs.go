@@ -0,0 +1,203 @@
+/*
+ * @Description: 基于最小生成树的所有结点对瓶颈路径查询
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 21:58:50
+ * @LastEditTime: 2026-10-19 21:58:50
+ * @LastEditors:
+ *
+ *
+ * ## 最小生成树与瓶颈路径
+ *
+ * 在无向图中，最小生成树上u到v的唯一路径就是一条最小最大路径：若存在另一条路径的最大权重更小，那么用它替换树路径上的最大边可以得到权重更小的生成树，
+ * 矛盾。同理，最大生成树上的路径就是最宽路径。因此可以先用Kruskal算法求出最小(或最大)生成森林，再在森林上回答任意结点对的查询：
+ *
+ * - u、v不在同一棵树中时，v从u不可达
+ * - 否则瓶颈值为树上u到v路径上边的最大(或最小)权重
+ *
+ * 森林的每棵树以其中一个结点为根，用倍增法预处理：up[k][v]为v的第2^k个祖先，best[k][v]为v到up[k][v]之间的边的瓶颈值。
+ * 查询时把u、v同时向上跳到最近公共祖先，沿途合并瓶颈值。
+ *
+ * 预处理的时间复杂度为O(ElgE+VlgV)，每次查询瓶颈值O(lgV)，查询路径的时间复杂度为路径的长度。
+ *
+ * 这里把图看作无向图：有向边(u,v)与(v,u)等价。有向图的瓶颈路径请使用BottleneckPath。
+ */
+package AllNodePairShortestPath
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/minimum_spanning_tree"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+type BottleneckAllPairs struct {
+	Mode  BottleneckMode
+	up    [][]int //up[k][v]为v的第2^k个祖先，根结点的祖先为它自己
+	best  [][]int //best[k][v]为v到up[k][v]之间的边的瓶颈值
+	depth []int
+	tree  []int //tree[v]为v所在的树的根结点，-1表示v不是图中的结点
+}
+
+/**
+ * @description: 创建所有结点对瓶颈路径查询
+ * @param mode: BOTTLENECK_WIDEST或BOTTLENECK_MINIMAX
+ */
+func NewBottleneckAllPairs(mode BottleneckMode) *BottleneckAllPairs {
+	return &BottleneckAllPairs{Mode: mode}
+}
+
+/**
+ * @description: 预处理：求出最小(或最大)生成森林并建立倍增表
+ * @param graph: 图，看作无向图
+ * @return: error
+ *
+ * KruskalMST要求结点为SetVertex，所以在图的拷贝上执行。最宽路径需要最大生成树，把拷贝中边的权重取相反数即可。
+ */
+func (a *BottleneckAllPairs) Build(graph *Graph) error {
+	if graph == nil {
+		return errors.New("BottleneckAllPairs error: graph must not be nil!")
+	}
+	num := graph.N()
+	sign := 1
+	if a.Mode == BOTTLENECK_WIDEST {
+		sign = -1
+	}
+	forest := NewGraph(0, num, func(key, id int) IVertex {
+		return NewSetVertex(key, id)
+	}, GRAPH_REPRESENTION_ADJ)
+	for i, vertex := range graph.Vertexes {
+		if vertex != nil {
+			forest.AddVertex(0, i)
+		}
+	}
+	for _, edge := range graph.EdgeTuples() {
+		forest.AddEdge(NewTuple(edge.First, edge.Second, sign*edge.Third))
+	}
+	_, edges, err := NewKruskalMST().Generate(forest, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	type treeEdge struct{ to, weight int }
+	adj := make([][]treeEdge, num)
+	for _, edge := range edges {
+		adj[edge.First] = append(adj[edge.First], treeEdge{edge.Second, sign * edge.Third})
+		adj[edge.Second] = append(adj[edge.Second], treeEdge{edge.First, sign * edge.Third})
+	}
+
+	levels := 1
+	for 1<<levels < num {
+		levels++
+	}
+	a.up = make([][]int, levels)
+	a.best = make([][]int, levels)
+	for k := range a.up {
+		a.up[k] = make([]int, num)
+		a.best[k] = make([]int, num)
+	}
+	a.depth = make([]int, num)
+	a.tree = make([]int, num)
+	for v := range a.tree {
+		a.tree[v] = -1
+	}
+
+	//************* 广度优先遍历每棵树，记录父结点  ***************
+	identity := a.Mode.Identity()
+	for root := 0; root < num; root++ {
+		if graph.Vertexes[root] == nil || a.tree[root] >= 0 {
+			continue
+		}
+		a.tree[root] = root
+		a.up[0][root], a.best[0][root] = root, identity
+		queue := []int{root}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, edge := range adj[u] {
+				if a.tree[edge.to] >= 0 {
+					continue
+				}
+				a.tree[edge.to] = root
+				a.depth[edge.to] = a.depth[u] + 1
+				a.up[0][edge.to], a.best[0][edge.to] = u, edge.weight
+				queue = append(queue, edge.to)
+			}
+		}
+	}
+	for k := 1; k < levels; k++ {
+		for v := 0; v < num; v++ {
+			if a.tree[v] < 0 {
+				continue
+			}
+			mid := a.up[k-1][v]
+			a.up[k][v] = a.up[k-1][mid]
+			a.best[k][v] = a.Mode.Combine(a.best[k-1][v], a.best[k-1][mid])
+		}
+	}
+	return nil
+}
+
+// 结点的数目
+func (a *BottleneckAllPairs) N() int {
+	return len(a.tree)
+}
+
+// 结点v是否从u可达
+func (a *BottleneckAllPairs) Reachable(u, v int) bool {
+	return u >= 0 && u < a.N() && v >= 0 && v < a.N() && a.tree[u] >= 0 && a.tree[u] == a.tree[v]
+}
+
+/**
+ * @description: u到v的瓶颈值，u=v时为BottleneckMode.Identity()
+ * @return: 瓶颈值；v从u不可达时返回*UnreachableError
+ */
+func (a *BottleneckAllPairs) Value(u, v int) (int, error) {
+	if !a.Reachable(u, v) {
+		return 0, &UnreachableError{Source: u, Target: v}
+	}
+	value := a.Mode.Identity()
+	if a.depth[u] < a.depth[v] {
+		u, v = v, u
+	}
+	for k := len(a.up) - 1; k >= 0; k-- {
+		if a.depth[u]-1<<k >= a.depth[v] {
+			value = a.Mode.Combine(value, a.best[k][u])
+			u = a.up[k][u]
+		}
+	}
+	if u == v {
+		return value, nil
+	}
+	for k := len(a.up) - 1; k >= 0; k-- {
+		if a.up[k][u] != a.up[k][v] {
+			value = a.Mode.Combine(value, a.Mode.Combine(a.best[k][u], a.best[k][v]))
+			u, v = a.up[k][u], a.up[k][v]
+		}
+	}
+	return a.Mode.Combine(value, a.Mode.Combine(a.best[0][u], a.best[0][v])), nil
+}
+
+/**
+ * @description: 生成森林上u到v的路径，即一条瓶颈路径
+ * @return: 路径上的结点`id`，从u到v；v从u不可达时返回nil
+ */
+func (a *BottleneckAllPairs) Path(u, v int) []int {
+	if !a.Reachable(u, v) {
+		return nil
+	}
+	head, tail := []int{u}, []int{v}
+	for u != v {
+		if a.depth[u] >= a.depth[v] {
+			u = a.up[0][u]
+			head = append(head, u)
+		} else {
+			v = a.up[0][v]
+			tail = append(tail, v)
+		}
+	}
+	Revert(tail)
+	return append(head, tail[1:]...)
+}
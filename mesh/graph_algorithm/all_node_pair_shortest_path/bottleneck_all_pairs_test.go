@@ -0,0 +1,77 @@
+/*
+ * @Description: 基于最小生成树的所有结点对瓶颈路径查询测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 21:58:50
+ * @LastEditTime: 2026-10-19 21:58:50
+ * @LastEditors:
+ */
+package AllNodePairShortestPath
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+/**
+ * @description:基于最小生成树的瓶颈路径查询与修改的Dijkstra算法的结果一致。图中有两个连通分量以及一个孤立结点
+ */
+func TestBottleneckAllPairs(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	NUM := 40
+	for seed := int64(1); seed <= 3; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		graph := NewGraph(0, NUM+1, creator, GRAPH_REPRESENTION_ADJ) //结点NUM为空结点
+		for i := 0; i < NUM; i++ {
+			graph.AddVertex(0, i)
+		}
+		for k := 0; k < 3*NUM; k++ {
+			u, v := r.Intn(NUM-1), r.Intn(NUM-1) //结点NUM-1是孤立结点
+			if u == v || u < NUM/2 != (v < NUM/2) {
+				continue
+			}
+			if has, _ := graph.HasEdge(u, v); !has {
+				w := r.Intn(100) - 20
+				graph.AddEdge(NewTuple(u, v, w))
+				graph.AddEdge(NewTuple(v, u, w))
+			}
+		}
+
+		for _, mode := range []BottleneckMode{BOTTLENECK_WIDEST, BOTTLENECK_MINIMAX} {
+			all_pairs := NewBottleneckAllPairs(mode)
+			EXPECT_EQ(all_pairs.Build(graph), nil, t)
+			for u := 0; u < NUM; u++ {
+				tree, _ := NewBottleneckPath(mode).ShortestPath(graph, u)
+				for v := 0; v < NUM; v++ {
+					EXPECT_EQ(all_pairs.Reachable(u, v), tree.Reachable(v), t)
+					value, err := all_pairs.Value(u, v)
+					if !tree.Reachable(v) {
+						_, is_unreachable := err.(*UnreachableError)
+						EXPECT_EQ(is_unreachable, true, t)
+						EXPECT_EQ(all_pairs.Path(u, v) == nil, true, t)
+						continue
+					}
+					expect, _ := tree.Value(v)
+					EXPECT_EQ(value, expect, t)
+					path := all_pairs.Path(u, v)
+					EXPECT_EQ(path[0], u, t)
+					EXPECT_EQ(path[len(path)-1], v, t)
+					along := mode.Identity()
+					for i := 0; i+1 < len(path); i++ {
+						w, _ := graph.Weight(path[i], path[i+1])
+						along = mode.Combine(along, w)
+					}
+					EXPECT_EQ(along, expect, t)
+				}
+			}
+			EXPECT_EQ(all_pairs.Reachable(0, NUM), false, t)
+		}
+	}
+	EXPECT_EQ(NewBottleneckAllPairs(BOTTLENECK_WIDEST).Build(nil) != nil, true, t)
+}
@@ -52,12 +52,14 @@ package AllNodePairShortestPath
 
 import (
 	"errors"
+	"sync"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/common"
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
 )
 
 type FloydWarshallSP struct {
+	Workers int //并行计算的goroutine数目，不超过1时串行计算
 }
 
 func NewFloydWarshallSP() *FloydWarshallSP {
@@ -97,6 +99,8 @@ func NewFloydWarshallSP() *FloydWarshallSP {
 * ### 算法性能
 *
 * 时间复杂度 O(V^3)
+*
+* Workers大于1时，第k轮把矩阵的行分成Workers块，由多个goroutine并行计算，每一轮结束时同步一次。结果与串行计算完全相同
 */
 func (a *FloydWarshallSP) ShortestPath(graph *Graph) ([][]int, [][]int, error) {
 
@@ -127,6 +131,11 @@ func (a *FloydWarshallSP) ShortestPath(graph *Graph) ([][]int, [][]int, error) {
 			}
 		}
 	}
+	if a.Workers > 1 {
+		a.parallel(D, P)
+		return D, P, nil
+	}
+
 	//**************  计算矩阵D和前驱矩阵P ******************
 	for k := 0; k < num; k++ {
 		newD := NewMatrix(num, 0)
@@ -134,14 +143,8 @@ func (a *FloydWarshallSP) ShortestPath(graph *Graph) ([][]int, [][]int, error) {
 		for i := 0; i < num; i++ {
 			for j := 0; j < num; j++ {
 				// D中存放的是D<k-1>,P中存放的是P<k-1>
-				sum := 0
-
-				//如果k节点跟i或者j不通，则一定不在p(i,j)的最短路径上
-				if Is_Unlimit(D[i][k]) || Is_Unlimit(D[k][j]) {
-					sum = unlimit
-				} else {
-					sum = D[i][k] + D[k][j]
-				}
+				//如果k节点跟i或者j不通，则一定不在p(i,j)的最短路径上。饱和加法保证sum不会溢出
+				sum := AddDistance(D[i][k], D[k][j])
 
 				//如果原来的最短路径值更小，则k不在p(i,j)的最短路径上
 				if D[i][j] <= sum {
@@ -163,3 +166,48 @@ func (a *FloydWarshallSP) ShortestPath(graph *Graph) ([][]int, [][]int, error) {
 
 	return D, P, nil
 }
+
+/**
+* @description: 并行计算矩阵D和前驱矩阵P，原地更新
+* @param D: 初始的权重矩阵D<0>
+* @param P: 初始的前驱矩阵P<0>
+*
+* 第k轮中，第i行的新值只依赖于第i行的旧值、d_i_k<k-1>以及第k行的旧值。先保存第k行，每一行在更新之前再保存d_i_k，
+* 这样各行可以原地、并行地更新，得到的D<k>、P<k>与串行计算完全相同(即使图中存在权重为负值的环路)。
+ */
+func (a *FloydWarshallSP) parallel(D, P [][]int) {
+	num := len(D)
+	workers := a.Workers
+	if workers > num {
+		workers = num
+	}
+	rowD := make([]int, num)
+	rowP := make([]int, num)
+	for k := 0; k < num; k++ {
+		copy(rowD, D[k])
+		copy(rowP, P[k])
+
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(begin, end int) {
+				defer wg.Done()
+				for i := begin; i < end; i++ {
+					dik := D[i][k]
+					if Is_Unlimit(dik) {
+						continue //sum恒为正无穷，第i行不变
+					}
+					Di, Pi := D[i], P[i]
+					for j := 0; j < num; j++ {
+						sum := AddDistance(dik, rowD[j])
+						if Di[j] > sum {
+							Di[j] = sum
+							Pi[j] = rowP[j]
+						}
+					}
+				}
+			}(w*num/workers, (w+1)*num/workers)
+		}
+		wg.Wait()
+	}
+}
@@ -53,6 +53,7 @@ package AllNodePairShortestPath
 
 import (
 	"errors"
+	"sync"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
 
@@ -61,6 +62,7 @@ import (
 )
 
 type JohnsonSP struct {
+	Workers int //并行执行Dijkstra算法的goroutine数目，不超过1时串行计算
 }
 
 func NewJohnsonSP() *JohnsonSP {
@@ -118,7 +120,7 @@ func (a *JohnsonSP) graph_add_one_vertex(graph *Graph) (*Graph, error) {
 * - 重赋权重：
 *   - 创建新图 new_graph
 *   - 对新图执行 bellman_ford 的调用，源点为新创建的结点s
-*   - 如果有负权值的环路，则抛出异常。
+*   - 如果有负权值的环路，则返回*NegativeCycleError，其中包含一个权重为负值的环路
 *   - 如果没有负权重环路，则创建h函数，并对new_graph中的所有边执行重新赋权
 * - 在 new_graph上，除了新的顶点s之外的所有顶点v,以v为源顶点执行dijkstra过程。D[i][j]等于 new_graph 中以i为源点到j的最短路径的权重的修正值，
 * 修正的方法就是重新赋权的逆过程。
@@ -127,11 +129,23 @@ func (a *JohnsonSP) graph_add_one_vertex(graph *Graph) (*Graph, error) {
 * ###算法性能
 *
 *  时间复杂度 O（V^2 lgV + VE)
+*
+* Workers大于1时，第四阶段使用一个goroutine池：每个goroutine持有新图的一份拷贝(Dijkstra算法会修改顶点的key和父结点)，
+* 从源点的通道中依次取出源点执行Dijkstra算法，各自填写D的不同行。结果与串行计算完全相同
  */
 func (a *JohnsonSP) ShortestPath(graph *Graph) ([][]int, error) {
+	D, _, err := a.shortestPath(graph)
+	return D, err
+}
+
+/**
+* @description: Johnson算法，同时返回前驱矩阵
+* @return: 权重矩阵D；前驱矩阵P，p_i_j为从结点i到j的一条最短路径上j的前驱结点，不存在时为-1；error
+ */
+func (a *JohnsonSP) shortestPath(graph *Graph) ([][]int, [][]int, error) {
 
 	if graph == nil {
-		return nil, errors.New("johnson error: graph must not be nil!")
+		return nil, nil, errors.New("johnson error: graph must not be nil!")
 	}
 
 	//*******************  第一阶段 重赋权值  **************
@@ -144,17 +158,22 @@ func (a *JohnsonSP) ShortestPath(graph *Graph) ([][]int, error) {
 	bellmanFord := NewBellmanFordShortestPath()
 	//新顶点s的id为 num，AddVertex时候设定的
 	//bellmanFord算法之后，会算出每个节点到s点的最短路径，并且设定好Parent关系
-	b, _ := bellmanFord.ShortestPath(new_graph, num)
+	b, err := bellmanFord.ShortestPath(new_graph, num)
 	if !b {
-		//不能有权重为负的环路
-		return nil, errors.New("johnson error: graph has a nagative-weight circle!")
+		//不能有权重为负的环路，新顶点s没有入边，所以环路中只包含原图的顶点
+		if err == nil {
+			err = errors.New("johnson error: graph has a nagative-weight circle!")
+		}
+		return nil, nil, err
 	}
 
 	//*******************  第三阶段 bellmanFord已经获得了新的权重，H函数将new_graph的权重调整到非负  **************
 	//创建h函数， h(v)=delt(s,v)
 	H := make([]int, numNew)
 	for i := 0; i < num; i++ {
-		H[i] = new_graph.Vertexes[i].GetKey()
+		if vertex := new_graph.Vertexes[i]; vertex != nil {
+			H[i] = vertex.GetKey()
+		}
 	}
 
 	//通过重新赋值生成非负权重，可以对比该循环前后graph和new_graph的Matrix.Matrix属性
@@ -164,22 +183,99 @@ func (a *JohnsonSP) ShortestPath(graph *Graph) ([][]int, error) {
 			//只有边存在的情况下才调整
 			if has_edge {
 				wt, _ := new_graph.Weight(i, j)
-				new_graph.AdjustEdge(i, j, wt+H[i]-H[j])
+				//w+h(u)-h(v)非负；权重为正无穷的边调整之后仍为正无穷
+				new_graph.AdjustEdge(i, j, AddDistance(AddDistance(wt, H[i]), -H[j]))
 			}
 		}
 	}
 
 	//******************  第四阶段：在新图上以每个顶点为源点，计算单源最短路径  *********
+	if a.Workers > 1 {
+		D, P := a.parallel(new_graph, num, H)
+		return D, P, nil
+	}
 	dijkstra := NewDijkstra()
 	D := NewMatrix(num, 0)
+	P := NewMatrix(num, -1)
 	//剔除新顶点s作为源点
 	for i := 0; i < num; i++ {
 		//该算法要求所有边的权重都非负，所以需要H函数调整权重，调整之后还需要恢复权重
 		//dijkstra算法比bellmanFord算法性能更高一些，但是对graph有要求
 		dijkstra.ShortestPath(new_graph, i)
-		for j := 0; j < num; j++ {
-			D[i][j] = new_graph.Vertexes[j].GetKey() + H[j] - H[i] // 恢复权值
+		a.fillRow(new_graph, i, H, D, P)
+	}
+	return D, P, nil
+}
+
+/**
+* @description: 以i为源点执行Dijkstra算法之后，填写D和P的第i行
+ */
+func (a *JohnsonSP) fillRow(new_graph *Graph, i int, H []int, D, P [][]int) {
+	for j := range D[i] {
+		vertex := new_graph.Vertexes[j]
+		if vertex == nil || Is_Unlimit(vertex.GetKey()) { //不可达，不能恢复权值，否则可能变成"有限"的值
+			D[i][j] = Unlimit()
+			continue
+		}
+		D[i][j] = AddDistance(AddDistance(vertex.GetKey(), H[j]), -H[i]) // 恢复权值
+		if parent := vertex.GetParent(); parent != nil {
+			P[i][j] = parent.GetID()
+		}
+	}
+}
+
+/**
+* @description: 并行执行Johnson算法的第四阶段
+* @param new_graph: 已经重新赋权的新图
+* @param num: 原图的顶点数目
+* @param H: h函数
+* @return: 权重矩阵D；前驱矩阵P
+ */
+func (a *JohnsonSP) parallel(new_graph *Graph, num int, H []int) ([][]int, [][]int) {
+	D := NewMatrix(num, 0)
+	P := NewMatrix(num, -1)
+	sources := make(chan int, num)
+	for i := 0; i < num; i++ {
+		sources <- i
+	}
+	close(sources)
+
+	workers := a.Workers
+	if workers > num {
+		workers = num
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		graph := cloneGraph(new_graph)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dijkstra := NewDijkstra()
+			for i := range sources {
+				dijkstra.ShortestPath(graph, i)
+				a.fillRow(graph, i, H, D, P)
+			}
+		}()
+	}
+	wg.Wait()
+	return D, P
+}
+
+/**
+* @description: 复制一个图：顶点的key、`id`以及所有的边，表示法与原图相同
+ */
+func cloneGraph(graph *Graph) *Graph {
+	var result *Graph
+	if graph.Matrix != nil {
+		result = NewGraph(graph.Matrix.InvalidWeight(), graph.N(), graph.VertexCreator)
+	} else {
+		result = NewGraph(0, graph.N(), graph.VertexCreator, GRAPH_REPRESENTION_ADJ)
+	}
+	for _, vertex := range graph.Vertexes {
+		if vertex != nil {
+			result.AddVertex(vertex.GetKey(), vertex.GetID())
 		}
 	}
-	return D, nil
+	result.AddEdges(graph.EdgeTuples())
+	return result
 }
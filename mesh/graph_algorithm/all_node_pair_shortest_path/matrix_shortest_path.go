@@ -75,7 +75,8 @@ func (a *MatrixSP) extend_path(L [][]int, W [][]int) ([][]int, error) {
 				//下式的表意为：如果[i,j]中间能找到一个分隔点k,让i到j的距离经过k之后更小，则更新[i,j]最短路径值
 				//注意:k遍历时候L[i,k]可能不通(unlimit)，W[k,j]也可能不通(unlimit)
 				//L[i][k]+W[k][j]意思为，3条边时候的最短路径L[i][k]+当前这条边E(k,j)共4条边构成的路径的权重是多少
-				newL[i][j] = MinInt(newL[i][j], L[i][k]+W[k][j])
+				//使用饱和加法，避免正无穷加上负的权重之后变成"有限"的值
+				newL[i][j] = MinInt(newL[i][j], AddDistance(L[i][k], W[k][j]))
 			}
 		}
 	}
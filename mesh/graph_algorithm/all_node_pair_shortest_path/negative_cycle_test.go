@@ -0,0 +1,46 @@
+/*
+ * @Description: Johnson算法检测权重为负值的环路测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 19:21:07
+ * @LastEditTime: 2026-10-19 19:21:07
+ * @LastEditors:
+ */
+package AllNodePairShortestPath
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+/**
+ * @description: 存在权重为负值的环路时，Johnson算法返回*NegativeCycleError
+ */
+func TestJohnsonNegativeCycle(t *testing.T) {
+	NUM := 5
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	_graph := NewGraph(Unlimit(), NUM, creator)
+	for i := 0; i < NUM; i++ {
+		_graph.AddVertex(0)
+	}
+	_graph.AddEdge(NewTuple(0, 1, 3))
+	_graph.AddEdge(NewTuple(1, 2, 2))
+	_graph.AddEdge(NewTuple(2, 3, -4))
+	_graph.AddEdge(NewTuple(3, 1, 1)) //环路1-->2-->3-->1的权重为-1
+	_graph.AddEdge(NewTuple(3, 4, 5))
+
+	_, err := NewJohnsonSP().ShortestPath(_graph)
+	cycle, ok := err.(*NegativeCycleError)
+	EXPECT_EQ(ok, true, t)
+	EXPECT_EQ(cycle.Weight, -1, t)
+	EXPECT_EQ(len(cycle.Cycle), 3, t)
+	for i := range cycle.Cycle {
+		has, _ := _graph.HasEdge(cycle.Cycle[i], cycle.Cycle[(i+1)%len(cycle.Cycle)])
+		EXPECT_EQ(has, true, t)
+	}
+}
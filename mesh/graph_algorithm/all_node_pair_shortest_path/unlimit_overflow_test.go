@@ -0,0 +1,58 @@
+/*
+ * @Description: int边界附近权重的所有结点对最短路径测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 20:04:37
+ * @LastEditTime: 2026-10-19 20:04:37
+ * @LastEditors:
+ */
+package AllNodePairShortestPath
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+/**
+ * @description:int边界附近的权重：各个所有结点对最短路径算法都不能把正无穷变成"有限"的值，也不能溢出
+ */
+func TestAllPairsUnlimitOverflow(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	graph := NewGraph(Unlimit(), 7, creator)
+	for i := 0; i < 7; i++ {
+		graph.AddVertex(0)
+	}
+	//0-->1-->4的权重为1+INT_MAX，0-->5-->6的权重约为INT_MAX/4；2-->3的权重为-INT_MAX/4，3-->2的权重为INT_MAX/4+1
+	for _, edge := range [][3]int{{0, 1, 1}, {1, 4, INT_MAX}, {0, 5, INT_MAX / 8}, {5, 6, INT_MAX / 8}, {2, 3, -INT_MAX / 4}, {3, 2, INT_MAX/4 + 1}} {
+		graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+	}
+	check := func(D [][]int) {
+		EXPECT_EQ(D[0][6], INT_MAX/8*2, t)
+		EXPECT_EQ(D[2][3], -INT_MAX/4, t)
+		EXPECT_EQ(D[3][2], INT_MAX/4+1, t)
+		for _, pair := range [][2]int{{0, 2}, {0, 3}, {0, 4}, {1, 4}, {2, 0}, {3, 6}} {
+			EXPECT_EQ(Is_Unlimit(D[pair[0]][pair[1]]), true, t)
+		}
+	}
+
+	D, _, _ := NewFloydWarshallSP().ShortestPath(graph)
+	check(D)
+	D, _, _ = (&FloydWarshallSP{Workers: 3}).ShortestPath(graph)
+	check(D)
+	D, _ = NewMatrixSP().ShortestPath(graph)
+	check(D)
+	D, _ = NewMatrixSP().ShortestPathFast(graph)
+	check(D)
+	D, err := NewJohnsonSP().ShortestPath(graph)
+	EXPECT_EQ(err, nil, t)
+	check(D)
+	result, _ := NewJohnsonSP().ShortestPathResult(graph)
+	_, err = result.Dist(0, 4)
+	_, is_unreachable := err.(*UnreachableError)
+	EXPECT_EQ(is_unreachable, true, t)
+}
@@ -0,0 +1,262 @@
+/*
+ * @Description: 有向图的支配树，Lengauer-Tarjan算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 10:12:40
+ * @LastEditTime: 2026-10-19 10:12:40
+ * @LastEditors:
+ *
+ *
+ * 给定有向图G=(V,E)以及入口结点r（例如控制流图的入口基本块）。若从r到结点w的每一条路径都经过结点v，则称v支配w。
+ * 每个结点都支配它自己，r支配所有从r可达的结点。
+ *
+ * - 直接支配结点idom(w)：在w的所有严格支配结点(不等于w的支配结点)中，离w最近的那个结点。
+ * - 支配树：把每个结点w挂在idom(w)之下得到的以r为根的树，w的支配结点就是支配树中从r到w路径上的所有结点。
+ * - 支配边界DF(v)：所有满足"v支配w的某个前驱，但v不严格支配w"的结点w构成的集合，它是构造SSA形式时插入phi函数的位置。
+ * - 后向支配：在翻转图G_T上以出口结点为入口计算出的支配关系，即从w到出口的每一条路径都经过v，则称v后向支配w。
+ *
+ * Lengauer-Tarjan算法步骤：
+ *
+ * - 从r出发进行深度优先搜索，为每个结点编号(dfn)，并记录深度优先树中的父结点
+ * - 按照dfn的降序计算每个结点w的半支配结点semi(w)：在所有前驱v中，若dfn(v)<dfn(w)，候选值为v；
+ *   否则候选值为v在已处理森林中的祖先里semi最小的结点的semi。semi(w)取所有候选值中dfn最小者
+ * - 利用半支配定理隐式地求出idom：对于semi(w)到w之间(不含semi(w))semi最小的结点u，若semi(u)=semi(w)则idom(w)=semi(w)，
+ *   否则idom(w)=idom(u)
+ * - 最后按照dfn的升序修正那些隐式确定的idom
+ *
+ * 森林中的祖先查询使用路径压缩实现，时间复杂度O(E lgV)
+ */
+package BasicGraph
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type DominatorTree struct {
+}
+
+func NewDominatorTree() *DominatorTree {
+	return &DominatorTree{}
+}
+
+/**
+ * @description: 计算从入口结点出发的直接支配结点
+ * @param graph: 有向图，例如控制流图
+ * @param entry_id: 入口结点的`id`
+ * @return: idom数组，idom[v]为结点v的直接支配结点的`id`；idom[entry_id]=entry_id；从入口不可达的结点(包括空结点)为-1
+ *
+ * 算法只读取图的边，不会修改顶点的`key`和`parent`，因此适用于任何类型的顶点
+ */
+func (a *DominatorTree) Dominators(graph *Graph, entry_id int) ([]int, error) {
+	if graph == nil {
+		return nil, errors.New("Dominators error: graph must not be nil!")
+	}
+
+	num := graph.N()
+	if entry_id < 0 || entry_id >= num || graph.Vertexes[entry_id] == nil {
+		return nil, errors.New("Dominators error: entry_id muse belongs [0,N) and entry vertex must not be nil!")
+	}
+
+	//*********** 前驱表 ****************
+	preds := make([][]int, num)
+	for _, edge := range graph.EdgeTuples() {
+		preds[edge.Second] = append(preds[edge.Second], edge.First)
+	}
+
+	dfn := make([]int, num)       //结点的深度优先编号，-1表示不可达
+	vertex := make([]int, 0, num) //vertex[i]为编号为i的结点
+	parent := make([]int, num)    //深度优先树中的父结点
+	semi := make([]int, num)      //半支配结点的编号
+	idom := make([]int, num)
+	ancestor := make([]int, num) //已处理森林中的祖先
+	label := make([]int, num)    //祖先链上semi最小的结点
+	bucket := make([][]int, num) //semi(w)=v的所有结点w存放在bucket[v]中
+	for i := 0; i < num; i++ {
+		dfn[i] = -1
+		parent[i] = -1
+		idom[i] = -1
+		ancestor[i] = -1
+		label[i] = i
+	}
+
+	//*********** 第一阶段 深度优先搜索编号 ****************
+	//控制流图可能很深，这里使用显式的栈，避免递归过深
+	type frame struct {
+		id    int
+		edges []int
+	}
+	successors := func(id int) []int {
+		edges, _ := graph.VertexEdgeTuples(id)
+		ids := make([]int, len(edges))
+		for i, edge := range edges {
+			ids[i] = edge.Second
+		}
+		return ids
+	}
+	dfn[entry_id] = 0
+	semi[entry_id] = 0
+	vertex = append(vertex, entry_id)
+	stack := []*frame{{id: entry_id, edges: successors(entry_id)}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if len(top.edges) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next_id := top.edges[0]
+		top.edges = top.edges[1:]
+		if dfn[next_id] < 0 {
+			dfn[next_id] = len(vertex)
+			semi[next_id] = dfn[next_id]
+			parent[next_id] = top.id
+			vertex = append(vertex, next_id)
+			stack = append(stack, &frame{id: next_id, edges: successors(next_id)})
+		}
+	}
+
+	//*********** 第二阶段 计算半支配结点，并隐式确定直接支配结点 ****************
+	for i := len(vertex) - 1; i > 0; i-- {
+		w := vertex[i]
+		for _, v := range preds[w] {
+			if dfn[v] < 0 { //从入口不可达的前驱不影响支配关系
+				continue
+			}
+			u := a.eval(v, ancestor, label, semi)
+			if semi[u] < semi[w] {
+				semi[w] = semi[u]
+			}
+		}
+		bucket[vertex[semi[w]]] = append(bucket[vertex[semi[w]]], w)
+		ancestor[w] = parent[w] //link(parent(w),w)
+
+		p := parent[w]
+		for _, v := range bucket[p] {
+			u := a.eval(v, ancestor, label, semi)
+			if semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = p
+			}
+		}
+		bucket[p] = nil
+	}
+
+	//*********** 第三阶段 修正隐式确定的直接支配结点 ****************
+	for i := 1; i < len(vertex); i++ {
+		w := vertex[i]
+		if idom[w] != vertex[semi[w]] {
+			idom[w] = idom[idom[w]]
+		}
+	}
+	idom[entry_id] = entry_id
+	return idom, nil
+}
+
+/**
+ * @description: 返回结点v在已处理森林中的祖先链上(不含树根)semi最小的结点，同时进行路径压缩
+ */
+func (a *DominatorTree) eval(v int, ancestor, label, semi []int) int {
+	if ancestor[v] < 0 {
+		return v
+	}
+	//从v往上收集需要压缩的路径，再从靠近树根的一端开始压缩
+	path := []int{}
+	for u := v; ancestor[ancestor[u]] >= 0; u = ancestor[u] {
+		path = append(path, u)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		u := path[i]
+		anc := ancestor[u]
+		if semi[label[anc]] < semi[label[u]] {
+			label[u] = label[anc]
+		}
+		ancestor[u] = ancestor[anc]
+	}
+	return label[v]
+}
+
+/**
+ * @description: 计算支配边界
+ * @param graph: 有向图，必须与计算idom时使用的图相同
+ * @param idom: Dominators返回的直接支配结点数组
+ * @return: df[v]为结点v的支配边界，按照`id`升序排列
+ *
+ * 对于每一个结点b的每一个可达前驱p，从p开始沿着支配树向上走，直到遇到idom(b)为止，途经的结点的支配边界都包含b
+ *
+ * 时间复杂度 O(E+|DF|)
+ */
+func (a *DominatorTree) DominanceFrontier(graph *Graph, idom []int) ([][]int, error) {
+	if graph == nil {
+		return nil, errors.New("DominanceFrontier error: graph must not be nil!")
+	}
+
+	num := graph.N()
+	if len(idom) != num {
+		return nil, errors.New("DominanceFrontier error: len(idom) must equal to graph.N()!")
+	}
+
+	df := make([][]int, num)
+	last := make([]int, num) //last[v]=b 表示b已经加入了df[v]，避免重复
+	for i := 0; i < num; i++ {
+		df[i] = []int{}
+		last[i] = -1
+	}
+
+	preds := make([][]int, num)
+	for _, edge := range graph.EdgeTuples() {
+		preds[edge.Second] = append(preds[edge.Second], edge.First)
+	}
+	//b按照升序处理，因此df[v]也是升序
+	for b := 0; b < num; b++ {
+		if idom[b] < 0 {
+			continue
+		}
+		for _, p := range preds[b] {
+			if idom[p] < 0 {
+				continue
+			}
+			for runner := p; ; runner = idom[runner] {
+				if runner == idom[b] && runner != b {
+					break
+				}
+				if last[runner] != b {
+					df[runner] = append(df[runner], b)
+					last[runner] = b
+				}
+				if runner == idom[runner] { //到达支配树的树根
+					break
+				}
+			}
+		}
+	}
+	return df, nil
+}
+
+/**
+ * @description: 计算后向支配结点
+ * @param graph: 有向图
+ * @param exit_id: 出口结点的`id`
+ * @return: ipdom数组，ipdom[v]为结点v的直接后向支配结点；ipdom[exit_id]=exit_id；不能到达出口的结点为-1
+ *
+ * 后向支配关系就是翻转图 graph.Inverse() 上以出口为入口的支配关系
+ */
+func (a *DominatorTree) PostDominators(graph *Graph, exit_id int) ([]int, error) {
+	if graph == nil {
+		return nil, errors.New("PostDominators error: graph must not be nil!")
+	}
+	return a.Dominators(graph.Inverse(), exit_id)
+}
+
+/**
+ * @description: 计算后向支配边界，即翻转图上的支配边界，可用于计算控制依赖
+ * @param graph: 有向图
+ * @param ipdom: PostDominators返回的直接后向支配结点数组
+ * @return: 每个结点的后向支配边界
+ */
+func (a *DominatorTree) PostDominanceFrontier(graph *Graph, ipdom []int) ([][]int, error) {
+	if graph == nil {
+		return nil, errors.New("PostDominanceFrontier error: graph must not be nil!")
+	}
+	return a.DominanceFrontier(graph.Inverse(), ipdom)
+}
@@ -0,0 +1,82 @@
+/*
+ * @Description: 支配树与支配边界测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 10:12:40
+ * @LastEditTime: 2026-10-19 10:12:40
+ * @LastEditors:
+ */
+package BasicGraph
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 支配树与支配边界
+ */
+func TestDominatorTree(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//****  控制流图：0-->1-->{2,3}-->4-->1(循环), 4-->5(出口), 6不可达  ****
+	//
+	//		0 --> 1 --> 2 --> 4 --> 5
+	//		      |           ^|
+	//		      +---> 3 ----+|
+	//		      ^------------+
+	NUM := 7
+	cfg := NewGraph(-1, NUM, creator)
+	for i := 0; i < NUM; i++ {
+		cfg.AddVertex(i)
+	}
+	cfg.AddEdge(NewTuple(0, 1, 1))
+	cfg.AddEdge(NewTuple(1, 2, 1))
+	cfg.AddEdge(NewTuple(1, 3, 1))
+	cfg.AddEdge(NewTuple(2, 4, 1))
+	cfg.AddEdge(NewTuple(3, 4, 1))
+	cfg.AddEdge(NewTuple(4, 1, 1))
+	cfg.AddEdge(NewTuple(4, 5, 1))
+	cfg.AddEdge(NewTuple(6, 5, 1))
+
+	dom := NewDominatorTree()
+	idom, _ := dom.Dominators(cfg, 0)
+	EXPECT_EQ(idom, []int{0, 0, 1, 1, 1, 4, -1}, t)
+
+	df, _ := dom.DominanceFrontier(cfg, idom)
+	EXPECT_EQ(df, [][]int{{}, {1}, {4}, {4}, {1}, {}, {}}, t)
+
+	//以5为出口的后向支配，0,1,4都必须经过4才能到达5
+	ipdom, _ := dom.PostDominators(cfg, 5)
+	EXPECT_EQ(ipdom, []int{1, 4, 4, 4, 5, 5, 5}, t)
+
+	pdf, _ := dom.PostDominanceFrontier(cfg, ipdom)
+	EXPECT_EQ(pdf, [][]int{{}, {4}, {1}, {1}, {4}, {}, {}}, t)
+
+	//****  入口结点位于环路上时，入口也在自己的支配边界中  ****
+	loop := NewGraph(-1, 3, creator)
+	for i := 0; i < 3; i++ {
+		loop.AddVertex(i)
+	}
+	loop.AddEdge(NewTuple(0, 1, 1))
+	loop.AddEdge(NewTuple(1, 2, 1))
+	loop.AddEdge(NewTuple(2, 0, 1))
+	idom, _ = dom.Dominators(loop, 0)
+	EXPECT_EQ(idom, []int{0, 0, 1}, t)
+	df, _ = dom.DominanceFrontier(loop, idom)
+	EXPECT_EQ(df, [][]int{{0}, {0}, {0}}, t)
+
+	//****  邻接表表示的图  ****
+	adj := NewGraphUserAjd(NUM, creator)
+	for i := 0; i < NUM; i++ {
+		adj.AddVertex(i)
+	}
+	adj.AddEdges(cfg.EdgeTuples())
+	idom, _ = dom.Dominators(adj, 0)
+	EXPECT_EQ(idom, []int{0, 0, 1, 1, 1, 4, -1}, t)
+	ipdom, _ = dom.PostDominators(adj, 5)
+	EXPECT_EQ(ipdom, []int{1, 4, 4, 4, 5, 5, 5}, t)
+}
@@ -0,0 +1,208 @@
+/*
+ * @Description: 图的k-core分解与退化序
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 16:05:48
+ * @LastEditTime: 2026-10-19 16:05:48
+ * @LastEditors:
+ *
+ *
+ * 无向图G的k-core是G中使得每个结点的度数都不小于k的极大子图。k-core可以由G反复删除度数小于k的结点得到，
+ * 并且(k+1)-core一定包含在k-core中。结点v的核数(core number)是包含v的k-core中最大的k。
+ *
+ * - 退化度(degeneracy)：所有结点核数的最大值，即存在非空k-core的最大的k
+ * - 退化序(degeneracy ordering)：反复删除当前度数最小的结点，删除的先后顺序。每个结点在序中靠后的邻居不超过退化度个
+ *
+ * 对于有向图，度数可以取入度或者出度：
+ *
+ * - 入度k-core：每个结点在子图中的入度都不小于k，删除结点v时它的后继结点的入度减1
+ * - 出度k-core：每个结点在子图中的出度都不小于k，删除结点v时它的前驱结点的出度减1
+ *
+ * Batagelj-Zaversnik算法：把结点按照度数用桶排序放入数组，数组中每个度数的桶是连续的一段。按顺序取出结点v，
+ * v当前的度数就是它的核数；对于v的每个度数大于v的邻居u，把u与其所在桶的第一个结点交换，然后把桶的边界后移一位，
+ * 这样u就移入了度数减1的桶。每次操作都是O(1)的，因此总的时间复杂度为O(V+E)。
+ *
+ * 自环不计入度数。核数可以用来对稠密子图的搜索进行剪枝：大小为k+1的团中每个结点的核数都不小于k。
+ */
+package BasicGraph
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+// k-core分解使用的度数
+type CoreMode int
+
+const (
+	CORE_MODE_UNDIRECTED CoreMode = iota //有向边(u,v)视为无向边u--v
+	CORE_MODE_IN                         //入度
+	CORE_MODE_OUT                        //出度
+)
+
+type KCore struct {
+}
+
+func NewKCore() *KCore {
+	return &KCore{}
+}
+
+/**
+ * @description: 计算每个结点的核数
+ * @param graph: 图
+ * @param mode: 使用的度数
+ * @return: core[v]为结点v的核数，空结点为-1；error
+ */
+func (a *KCore) CoreNumber(graph *Graph, mode CoreMode) ([]int, error) {
+	degree, neighbors, err := a.coreDegree(graph, mode)
+	if err != nil {
+		return nil, err
+	}
+	core, _ := coreDecomposition(graph, degree, neighbors)
+	return core, nil
+}
+
+/**
+ * @description: 计算退化序以及退化度
+ * @param graph: 图
+ * @param mode: 使用的度数
+ * @return: 所有非空结点的退化序，退化度(空图为0)；error
+ *
+ * 结点在退化序中出现的先后顺序与其核数一致，即核数不减
+ */
+func (a *KCore) DegeneracyOrder(graph *Graph, mode CoreMode) ([]int, int, error) {
+	degree, neighbors, err := a.coreDegree(graph, mode)
+	if err != nil {
+		return nil, 0, err
+	}
+	core, order := coreDecomposition(graph, degree, neighbors)
+	degeneracy := 0
+	for _, c := range core {
+		if c > degeneracy {
+			degeneracy = c
+		}
+	}
+	return order, degeneracy, nil
+}
+
+/**
+ * @description: 返回k-core中的结点
+ * @param graph: 图
+ * @param k: k值
+ * @param mode: 使用的度数
+ * @return: 核数不小于k的所有结点`id`，按照升序排列；error
+ */
+func (a *KCore) Core(graph *Graph, k int, mode CoreMode) ([]int, error) {
+	core, err := a.CoreNumber(graph, mode)
+	if err != nil {
+		return nil, err
+	}
+	result := []int{}
+	for v, c := range core {
+		if c >= k && c >= 0 {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+/**
+ * @description: 根据mode求出每个结点的度数，以及删除结点时度数需要减1的邻居
+ */
+func (a *KCore) coreDegree(graph *Graph, mode CoreMode) ([]int, [][]int, error) {
+	if graph == nil {
+		return nil, nil, errors.New("KCore error: graph must not be nil!")
+	}
+	num := graph.N()
+	degree := make([]int, num)
+	if mode == CORE_MODE_UNDIRECTED {
+		adj := graph.UndirectedAdjacency()
+		for v := range adj {
+			degree[v] = len(adj[v])
+		}
+		return degree, adj, nil
+	}
+	if mode != CORE_MODE_IN && mode != CORE_MODE_OUT {
+		return nil, nil, errors.New("KCore error: unknown core mode!")
+	}
+
+	neighbors := make([][]int, num)
+	for _, edge := range graph.EdgeTuples() {
+		if edge.First == edge.Second {
+			continue
+		}
+		if mode == CORE_MODE_IN {
+			degree[edge.Second]++
+			neighbors[edge.First] = append(neighbors[edge.First], edge.Second)
+		} else {
+			degree[edge.First]++
+			neighbors[edge.Second] = append(neighbors[edge.Second], edge.First)
+		}
+	}
+	return degree, neighbors, nil
+}
+
+/**
+ * @description: Batagelj-Zaversnik算法
+ * @param graph: 图
+ * @param degree: 每个结点的初始度数，计算过程中会被修改
+ * @param neighbors: neighbors[v]为删除v时度数需要减1的结点
+ * @return: 每个结点的核数(空结点为-1)，所有非空结点的退化序
+ */
+func coreDecomposition(graph *Graph, degree []int, neighbors [][]int) ([]int, []int) {
+	num := graph.N()
+	max_degree := 0
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil && degree[v] > max_degree {
+			max_degree = degree[v]
+		}
+	}
+
+	//*********** 按照度数进行桶排序，bin[d]为度数为d的桶在vert中的起始位置 ****************
+	bin := make([]int, max_degree+1)
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil {
+			bin[degree[v]]++
+		}
+	}
+	start := 0
+	for d := 0; d <= max_degree; d++ {
+		start, bin[d] = start+bin[d], start
+	}
+	vert := make([]int, start) //按照度数排列的结点
+	pos := make([]int, num)    //结点在vert中的位置
+	core := make([]int, num)
+	for v := 0; v < num; v++ {
+		core[v] = -1
+		if graph.Vertexes[v] != nil {
+			pos[v] = bin[degree[v]]
+			vert[pos[v]] = v
+			bin[degree[v]]++
+		}
+	}
+	for d := max_degree; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	bin[0] = 0
+
+	//*********** 按顺序删除结点 ****************
+	for i := 0; i < len(vert); i++ {
+		v := vert[i]
+		core[v] = degree[v]
+		for _, u := range neighbors[v] {
+			if degree[u] > degree[v] {
+				du := degree[u]
+				pu := pos[u]
+				pw := bin[du]
+				w := vert[pw]
+				if u != w { //u与桶中的第一个结点交换
+					pos[u], pos[w] = pw, pu
+					vert[pu], vert[pw] = w, u
+				}
+				bin[du]++
+				degree[u]--
+			}
+		}
+	}
+	return core, vert
+}
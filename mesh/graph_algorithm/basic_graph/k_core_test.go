@@ -0,0 +1,116 @@
+/*
+ * @Description: k-core分解与退化序测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 16:05:48
+ * @LastEditTime: 2026-10-19 16:05:48
+ * @LastEditors:
+ */
+package BasicGraph
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: k-core分解与退化序
+ */
+func TestKCore(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//****  {0,1,2,3}为完全图，4与0、3、5相连，5--6，7孤立  ****
+	graph := NewGraph(-1, 8, creator)
+	for i := 0; i < 8; i++ {
+		graph.AddVertex(i)
+	}
+	for _, e := range [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}, {4, 0}, {4, 3}, {4, 5}, {5, 6}} {
+		graph.AddEdge(NewTuple(e[0], e[1], 1))
+	}
+	kcore := NewKCore()
+	core, _ := kcore.CoreNumber(graph, CORE_MODE_UNDIRECTED)
+	EXPECT_EQ(core, []int{3, 3, 3, 3, 2, 1, 1, 0}, t)
+	order, degeneracy, _ := kcore.DegeneracyOrder(graph, CORE_MODE_UNDIRECTED)
+	EXPECT_EQ(len(order), 8, t)
+	EXPECT_EQ(degeneracy, 3, t)
+	vertexes, _ := kcore.Core(graph, 2, CORE_MODE_UNDIRECTED)
+	EXPECT_EQ(vertexes, []int{0, 1, 2, 3, 4}, t)
+
+	//****  有向图：0->1->2->0，0->2，3->0  ****
+	directed := NewGraph(-1, 4, creator)
+	for i := 0; i < 4; i++ {
+		directed.AddVertex(i)
+	}
+	for _, e := range [][]int{{0, 1}, {1, 2}, {2, 0}, {0, 2}, {3, 0}} {
+		directed.AddEdge(NewTuple(e[0], e[1], 1))
+	}
+	core, _ = kcore.CoreNumber(directed, CORE_MODE_IN)
+	EXPECT_EQ(core, []int{1, 1, 1, 0}, t)
+	core, _ = kcore.CoreNumber(directed, CORE_MODE_OUT)
+	EXPECT_EQ(core, []int{1, 1, 1, 1}, t)
+
+	//****  与朴素的反复删除法比较，并检验退化序：每个结点在序中靠后的邻居数不超过退化度  ****
+	RNUM := 40
+	random := NewGraph(-1, RNUM, creator)
+	for i := 0; i < RNUM; i++ {
+		random.AddVertex(i)
+	}
+	for i := 0; i < RNUM; i++ {
+		for j := i + 1; j < RNUM; j++ {
+			if (i*i*7+j*13+i*j)%9 < 2 {
+				random.AddEdge(NewTuple(i, j, 1))
+			}
+		}
+	}
+	adj := random.UndirectedAdjacency()
+	core, _ = kcore.CoreNumber(random, CORE_MODE_UNDIRECTED)
+	for k := 0; ; k++ {
+		alive := make([]bool, RNUM)
+		count := RNUM
+		for i := range alive {
+			alive[i] = true
+		}
+		for changed := true; changed; {
+			changed = false
+			for v := 0; v < RNUM; v++ {
+				if !alive[v] {
+					continue
+				}
+				deg := 0
+				for _, w := range adj[v] {
+					if alive[w] {
+						deg++
+					}
+				}
+				if deg < k {
+					alive[v] = false
+					count--
+					changed = true
+				}
+			}
+		}
+		for v := 0; v < RNUM; v++ {
+			EXPECT_EQ(alive[v], core[v] >= k, t)
+		}
+		if count == 0 {
+			break
+		}
+	}
+	order, degeneracy, _ = kcore.DegeneracyOrder(random, CORE_MODE_UNDIRECTED)
+	pos := make([]int, RNUM)
+	for i, v := range order {
+		pos[v] = i
+	}
+	for v := 0; v < RNUM; v++ {
+		later := 0
+		for _, w := range adj[v] {
+			if pos[w] > pos[v] {
+				later++
+			}
+		}
+		EXPECT_EQ(later <= degeneracy, true, t)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * @Description: 无向图的极大团枚举与最大团，Bron-Kerbosch算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 13:20:31
+ * @LastEditTime: 2026-10-19 13:20:31
+ * @LastEditors:
+ *
+ *
+ * 无向图G=(V,E)的团是V的一个子集C，C中任意两个结点之间都有边相连。如果C不是任何其他团的真子集，则称C为极大团；
+ * 结点数最多的团称为最大团。
+ *
+ * Bron-Kerbosch算法维护三个集合：
+ *
+ * - R：当前正在扩展的团
+ * - P：可以加入R的候选结点，P中的每个结点都与R中所有结点相邻
+ * - X：已经处理过的结点，X中的每个结点也与R中所有结点相邻，但包含它们的团已经枚举过了
+ *
+ * 当P和X都为空时，R就是一个极大团。算法对P中的每个结点v递归调用 BK(R+{v}, P∩N(v), X∩N(v))，然后把v从P移到X中。
+ *
+ * 两个优化：
+ *
+ * - 枢轴(pivot)：在P∪X中选取使|P∩N(u)|最大的结点u，只对P-N(u)中的结点进行分支。因为任何极大团要么包含u，
+ *   要么包含u的某个非邻居，所以不会漏掉极大团
+ * - 退化序(degeneracy ordering)：最外层按照退化序处理结点v，P只包含v在序中靠后的邻居，X只包含靠前的邻居。
+ *   若图的退化度为d，则每个最外层调用的P不超过d个结点，总时间为O(d*n*3^(d/3))，对稀疏图非常有效
+ *
+ * 极大团通过回调函数逐个输出，因此不需要在内存中保存全部结果
+ */
+package BasicGraph
+
+import (
+	"errors"
+	"sort"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type MaximalClique struct {
+}
+
+func NewMaximalClique() *MaximalClique {
+	return &MaximalClique{}
+}
+
+// 回调函数，参数为一个极大团中的结点`id`，按照升序排列，回调函数可以保留该切片
+type CliqueActionFunc func(clique []int)
+
+/**
+ * @description: Bron-Kerbosch的搜索状态
+ */
+type cliqueSearch struct {
+	adj     [][]int //无向邻接表，按`id`升序
+	action  CliqueActionFunc
+	maximum bool  //是否只求最大团
+	best    []int //目前为止找到的最大团
+}
+
+/**
+ * @description: 枚举无向图的所有极大团
+ * @param graph: 图，有向边(u,v)视为无向边u--v，自环被忽略
+ * @param action: 每找到一个极大团就调用一次
+ * @return: error
+ *
+ * 孤立结点自身构成一个只含一个结点的极大团
+ */
+func (a *MaximalClique) Enumerate(graph *Graph, action CliqueActionFunc) error {
+	if graph == nil {
+		return errors.New("MaximalClique error: graph must not be nil!")
+	}
+	if action == nil {
+		return errors.New("MaximalClique error: action must not be nil!")
+	}
+	s := &cliqueSearch{adj: graph.UndirectedAdjacency(), action: action}
+	s.run(graph)
+	return nil
+}
+
+/**
+ * @description: 求无向图的最大团
+ * @param graph: 图，有向边(u,v)视为无向边u--v，自环被忽略
+ * @return: 最大团的结点`id`，按照升序排列；如果有多个最大团，返回最先找到的一个
+ *
+ * 在Bron-Kerbosch的基础上进行分支限界：当|R|+|P|不超过目前最大团的大小时，该分支不可能产生更大的团，直接剪枝
+ */
+func (a *MaximalClique) Maximum(graph *Graph) ([]int, error) {
+	if graph == nil {
+		return nil, errors.New("MaximalClique error: graph must not be nil!")
+	}
+	s := &cliqueSearch{adj: graph.UndirectedAdjacency(), maximum: true, best: []int{}}
+	s.run(graph)
+	return s.best, nil
+}
+
+/**
+ * @description: 按照退化序执行最外层循环
+ */
+func (s *cliqueSearch) run(graph *Graph) {
+	num := graph.N()
+	degree := make([]int, num)
+	for v := range s.adj {
+		degree[v] = len(s.adj[v])
+	}
+	_, order := coreDecomposition(graph, degree, s.adj) //见k_core.go
+	pos := make([]int, num)
+	for i, v := range order {
+		pos[v] = i
+	}
+	for _, v := range order {
+		P := []int{}
+		X := []int{}
+		for _, w := range s.adj[v] {
+			if pos[w] > pos[v] {
+				P = append(P, w)
+			} else {
+				X = append(X, w)
+			}
+		}
+		s.expand([]int{v}, P, X)
+	}
+}
+
+/**
+ * @description: 带枢轴的Bron-Kerbosch递归过程
+ * @param R: 当前的团
+ * @param P: 候选结点，按`id`升序
+ * @param X: 已处理结点，按`id`升序
+ */
+func (s *cliqueSearch) expand(R, P, X []int) {
+	if s.maximum && len(R)+len(P) <= len(s.best) {
+		return //剪枝
+	}
+	if len(P) == 0 {
+		if len(X) == 0 {
+			s.report(R)
+		}
+		return
+	}
+
+	//*********** 选取枢轴u，使|P∩N(u)|最大 ****************
+	pivot := -1
+	max_cnt := -1
+	for _, cand := range [][]int{P, X} {
+		for _, u := range cand {
+			cnt := len(intersectSorted(P, s.adj[u]))
+			if cnt > max_cnt {
+				max_cnt = cnt
+				pivot = u
+			}
+		}
+	}
+
+	//*********** 只对P-N(u)中的结点分支 ****************
+	branch := []int{}
+	for _, v := range P {
+		if !containsSorted(s.adj[pivot], v) {
+			branch = append(branch, v)
+		}
+	}
+	for _, v := range branch {
+		newR := make([]int, len(R), len(R)+1)
+		copy(newR, R)
+		newR = append(newR, v)
+		s.expand(newR, intersectSorted(P, s.adj[v]), intersectSorted(X, s.adj[v]))
+
+		//v从P移到X
+		P = removeSorted(P, v)
+		X = insertSorted(X, v)
+		if s.maximum && len(R)+len(P) <= len(s.best) {
+			return
+		}
+	}
+}
+
+func (s *cliqueSearch) report(R []int) {
+	clique := make([]int, len(R))
+	copy(clique, R)
+	sort.Ints(clique)
+	if s.maximum {
+		if len(clique) > len(s.best) {
+			s.best = clique
+		}
+	} else {
+		s.action(clique)
+	}
+}
+
+// 两个升序切片的交集
+func intersectSorted(x, y []int) []int {
+	result := []int{}
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		if x[i] == y[j] {
+			result = append(result, x[i])
+			i++
+			j++
+		} else if x[i] < y[j] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+func containsSorted(x []int, v int) bool {
+	i := sort.SearchInts(x, v)
+	return i < len(x) && x[i] == v
+}
+
+func removeSorted(x []int, v int) []int {
+	i := sort.SearchInts(x, v)
+	if i < len(x) && x[i] == v {
+		result := make([]int, 0, len(x)-1)
+		result = append(result, x[:i]...)
+		return append(result, x[i+1:]...)
+	}
+	return x
+}
+
+func insertSorted(x []int, v int) []int {
+	i := sort.SearchInts(x, v)
+	result := make([]int, 0, len(x)+1)
+	result = append(result, x[:i]...)
+	result = append(result, v)
+	return append(result, x[i:]...)
+}
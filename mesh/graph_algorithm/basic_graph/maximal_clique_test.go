@@ -0,0 +1,118 @@
+/*
+ * @Description: 极大团与最大团测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 13:20:31
+ * @LastEditTime: 2026-10-19 13:20:31
+ * @LastEditors:
+ */
+package BasicGraph
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 无向图的极大团与最大团
+ */
+func TestMaximalClique(t *testing.T) {
+	NUM := 10
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//****  三角形{0,1,2}与{1,2,3}共享边1--2，3--4，5孤立，{6,7,8,9}为完全图  ****
+	graph := NewGraph(-1, NUM, creator)
+	for i := 0; i < NUM; i++ {
+		graph.AddVertex(i)
+	}
+	for _, e := range [][]int{{0, 1}, {0, 2}, {1, 2}, {1, 3}, {2, 3}, {3, 4}, {6, 7}, {6, 8}, {6, 9}, {7, 8}, {7, 9}, {8, 9}} {
+		graph.AddEdge(NewTuple(e[0], e[1], 1))
+		graph.AddEdge(NewTuple(e[1], e[0], 1)) //无向边用两条有向边表示
+	}
+
+	clique := NewMaximalClique()
+	cliques := [][]int{}
+	clique.Enumerate(graph, func(c []int) {
+		cliques = append(cliques, c)
+	})
+	sort.Slice(cliques, func(i, j int) bool {
+		return cliques[i][0] < cliques[j][0] || cliques[i][0] == cliques[j][0] && len(cliques[i]) < len(cliques[j])
+	})
+	EXPECT_EQ(cliques, [][]int{{0, 1, 2}, {1, 2, 3}, {3, 4}, {5}, {6, 7, 8, 9}}, t)
+
+	max, _ := clique.Maximum(graph)
+	EXPECT_EQ(max, []int{6, 7, 8, 9}, t)
+
+	//****  与暴力枚举比较：随机图中每个极大团都必须是团，且不能再扩展  ****
+	RNUM := 12
+	random := NewGraph(-1, RNUM, creator)
+	for i := 0; i < RNUM; i++ {
+		random.AddVertex(i)
+	}
+	for i := 0; i < RNUM; i++ {
+		for j := i + 1; j < RNUM; j++ {
+			if (i*7+j*13)%5 < 3 {
+				random.AddEdge(NewTuple(i, j, 1))
+			}
+		}
+	}
+	adj := random.UndirectedAdjacency()
+	connected := func(u, v int) bool {
+		for _, w := range adj[u] {
+			if w == v {
+				return true
+			}
+		}
+		return false
+	}
+	//暴力求所有极大团的个数以及最大团的大小
+	brute_count, brute_max := 0, 0
+	for mask := 1; mask < 1<<uint(RNUM); mask++ {
+		is_clique, maximal := true, true
+		for u := 0; u < RNUM && is_clique; u++ {
+			for v := u + 1; v < RNUM; v++ {
+				if mask&(1<<uint(u)) != 0 && mask&(1<<uint(v)) != 0 && !connected(u, v) {
+					is_clique = false
+					break
+				}
+			}
+		}
+		if !is_clique {
+			continue
+		}
+		size := 0
+		for w := 0; w < RNUM; w++ {
+			if mask&(1<<uint(w)) != 0 {
+				size++
+				continue
+			}
+			all := true
+			for u := 0; u < RNUM; u++ {
+				if mask&(1<<uint(u)) != 0 && !connected(u, w) {
+					all = false
+					break
+				}
+			}
+			if all {
+				maximal = false
+			}
+		}
+		if maximal {
+			brute_count++
+		}
+		if size > brute_max {
+			brute_max = size
+		}
+	}
+	count := 0
+	clique.Enumerate(random, func(c []int) {
+		count++
+	})
+	EXPECT_EQ(count, brute_count, t)
+	max, _ = clique.Maximum(random)
+	EXPECT_EQ(len(max), brute_max, t)
+}
@@ -0,0 +1,210 @@
+/*
+ * @Description: 2-SAT问题，基于强连通分量求解
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 16:48:09
+ * @LastEditTime: 2026-10-19 16:48:09
+ * @LastEditors:
+ *
+ *
+ * 2-SAT问题：给定n个布尔变量x_0...x_(n-1)，以及若干个形如 (a ∨ b) 的子句，其中a、b是某个变量或者某个变量的否定(称为文字)。
+ * 求一组变量的取值使得所有子句都为真，或者判定这样的取值不存在。
+ *
+ * 蕴含图：每个变量x对应两个结点，分别表示文字x和文字¬x。子句 (a ∨ b) 等价于 (¬a → b) 且 (¬b → a)，因此添加两条有向边
+ * ¬a-->b 和 ¬b-->a。图中的路径 a-->b 表示只要a为真，b就必须为真。
+ *
+ * - 如果存在变量x，使得x和¬x处于同一个强连通分量中，则x → ¬x 且 ¬x → x，问题无解
+ * - 否则问题有解：把强连通分量收缩后得到有向无环图，按照拓扑序，若x所在的分量排在¬x所在的分量之后，则令x为真，否则令x为假
+ *
+ * 无解时，x → ¬x 和 ¬x → x 这两条蕴含链上的子句构成一个不可满足核：仅这些子句就足以推出矛盾。
+ *
+ * 性能：时间复杂度O(n+m)，m为子句数
+ */
+package BasicGraph
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+type TwoSAT struct {
+	n       int
+	clauses []*Pair //子句中的两个文字
+}
+
+/**
+ * @description: 2-SAT无解时的冲突
+ */
+type TwoSATConflict struct {
+	Variable int   //x与¬x处于同一个强连通分量中的变量
+	Clauses  []int //推出 x → ¬x 以及 ¬x → x 所用到的子句的下标，按照蕴含链的顺序排列，可能有重复
+}
+
+/**
+ * @description: 创建一个2-SAT问题
+ * @param n: 变量的数目，变量的编号为[0,n)
+ */
+func NewTwoSAT(n int) *TwoSAT {
+	return &TwoSAT{n: n}
+}
+
+/**
+ * @description: 变量v取值为value的文字在蕴含图中的结点`id`：文字v为2v，文字¬v为2v+1
+ */
+func twoSATLiteral(v int, value bool) int {
+	if value {
+		return 2 * v
+	}
+	return 2*v + 1
+}
+
+/**
+ * @description: 添加子句 (x=x_value) ∨ (y=y_value)
+ * @param x: 第一个变量
+ * @param x_value: 第一个文字是x(true)还是¬x(false)
+ * @param y: 第二个变量
+ * @param y_value: 第二个文字是y(true)还是¬y(false)
+ * @return: 子句的下标；error
+ *
+ * 例如子句 (x ∨ ¬y) 为 AddClause(x, true, y, false)；x=y时可以表示单个文字，例如 AddClause(x, true, x, true) 强制x为真
+ */
+func (a *TwoSAT) AddClause(x int, x_value bool, y int, y_value bool) (int, error) {
+	if x < 0 || x >= a.n || y < 0 || y >= a.n {
+		return -1, errors.New("AddClause error: variable must belongs [0,n)!")
+	}
+	a.clauses = append(a.clauses, NewPair(twoSATLiteral(x, x_value), twoSATLiteral(y, y_value)))
+	return len(a.clauses) - 1, nil
+}
+
+/**
+ * @description: 构建蕴含图
+ * @return: 有2n个结点的蕴含图，结点2v表示文字v，结点2v+1表示文字¬v；顶点类型为DFSVertex，使用邻接表表示
+ */
+func (a *TwoSAT) ImplicationGraph() *Graph {
+	creator := func(key, id int) IVertex {
+		return NewDFSVertex(key, id)
+	}
+	graph := NewGraph(0, 2*a.n, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 2*a.n; i++ {
+		graph.AddVertex(i)
+	}
+	for _, c := range a.clauses {
+		graph.AddEdge(NewTuple(c.First^1, c.Second, 1)) //¬a-->b
+		graph.AddEdge(NewTuple(c.Second^1, c.First, 1)) //¬b-->a
+	}
+	return graph
+}
+
+/**
+ * @description: 求解2-SAT问题
+ * @return: 有解时返回每个变量的取值；无解时返回冲突；error
+ */
+func (a *TwoSAT) Solve() ([]bool, *TwoSATConflict, error) {
+	graph := a.ImplicationGraph()
+	num := graph.N()
+
+	//*********** 求强连通分量，单个结点自成一个分量 ****************
+	scc := &StrongConnectedComponent{}
+	components, err := scc.SetStrongConnectedComponent(graph)
+	if err != nil {
+		return nil, nil, err
+	}
+	comp := make([]int, num)
+	for i := range comp {
+		comp[i] = -1
+	}
+	for i, component := range components {
+		for _, v := range component {
+			comp[v] = i
+		}
+	}
+	count := len(components)
+	for v := 0; v < num; v++ {
+		if comp[v] < 0 {
+			comp[v] = count
+			count++
+		}
+	}
+
+	for x := 0; x < a.n; x++ {
+		if comp[2*x] == comp[2*x+1] {
+			return nil, a.conflict(graph, x), nil
+		}
+	}
+
+	//*********** 收缩图的拓扑排序 ****************
+	creator := func(key, id int) IVertex {
+		return NewDFSVertex(key, id)
+	}
+	dag := NewGraph(0, count, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < count; i++ {
+		dag.AddVertex(i)
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if comp[edge.First] != comp[edge.Second] {
+			dag.AddEdge(NewTuple(comp[edge.First], comp[edge.Second], 1))
+		}
+	}
+	sorted, err := NewTopologySort().Sort(dag)
+	if err != nil {
+		return nil, nil, err
+	}
+	rank := make([]int, count)
+	for i, c := range sorted {
+		rank[c] = i
+	}
+
+	values := make([]bool, a.n)
+	for x := 0; x < a.n; x++ {
+		values[x] = rank[comp[2*x]] > rank[comp[2*x+1]]
+	}
+	return values, nil, nil
+}
+
+/**
+ * @description: 构造变量x的冲突：用广度优先搜索分别找出 x → ¬x 和 ¬x → x 的蕴含链，再映射回子句
+ */
+func (a *TwoSAT) conflict(graph *Graph, x int) *TwoSATConflict {
+	//edge_clause[from][to]为产生蕴含边from-->to的第一个子句
+	edge_clause := make([]map[int]int, graph.N())
+	for i := range edge_clause {
+		edge_clause[i] = map[int]int{}
+	}
+	for i, c := range a.clauses {
+		for _, e := range [][2]int{{c.First ^ 1, c.Second}, {c.Second ^ 1, c.First}} {
+			if _, ok := edge_clause[e[0]][e[1]]; !ok {
+				edge_clause[e[0]][e[1]] = i
+			}
+		}
+	}
+
+	result := &TwoSATConflict{Variable: x, Clauses: []int{}}
+	for _, ends := range [][2]int{{2 * x, 2*x + 1}, {2*x + 1, 2 * x}} {
+		parent := make([]int, graph.N())
+		for i := range parent {
+			parent[i] = -1
+		}
+		parent[ends[0]] = ends[0]
+		queue := []int{ends[0]}
+		for len(queue) > 0 && parent[ends[1]] < 0 {
+			u := queue[0]
+			queue = queue[1:]
+			edges, _ := graph.VertexEdgeTuples(u)
+			for _, edge := range edges {
+				if parent[edge.Second] < 0 {
+					parent[edge.Second] = u
+					queue = append(queue, edge.Second)
+				}
+			}
+		}
+		path := []int{}
+		for v := ends[1]; v != ends[0]; v = parent[v] {
+			path = append(path, edge_clause[parent[v]][v])
+		}
+		Revert(path)
+		result.Clauses = append(result.Clauses, path...)
+	}
+	return result
+}
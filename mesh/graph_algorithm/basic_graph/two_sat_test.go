@@ -0,0 +1,93 @@
+/*
+ * @Description: 2-SAT测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 16:48:09
+ * @LastEditTime: 2026-10-19 16:48:09
+ * @LastEditors:
+ */
+package BasicGraph
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+)
+
+/**
+ * @description: 2-SAT
+ */
+func TestTwoSAT(t *testing.T) {
+	//****  (x0 ∨ ¬x1) ∧ (x1 ∨ x2) ∧ (¬x0 ∨ ¬x2) ∧ (x1)  ****
+	sat := NewTwoSAT(3)
+	sat.AddClause(0, true, 1, false)
+	sat.AddClause(1, true, 2, true)
+	sat.AddClause(0, false, 2, false)
+	sat.AddClause(1, true, 1, true)
+	values, conflict, _ := sat.Solve()
+	EXPECT_EQ(values, []bool{true, true, false}, t)
+	EXPECT_EQ(conflict == nil, true, t)
+
+	//****  (x0 ∨ x1) ∧ (x0 ∨ ¬x1) ∧ (¬x0 ∨ x1) ∧ (¬x0 ∨ ¬x1)，x2无关  ****
+	unsat := NewTwoSAT(3)
+	unsat.AddClause(2, true, 2, true)
+	unsat.AddClause(0, true, 1, true)
+	unsat.AddClause(0, true, 1, false)
+	unsat.AddClause(0, false, 1, true)
+	unsat.AddClause(0, false, 1, false)
+	values, conflict, _ = unsat.Solve()
+	EXPECT_EQ(values == nil, true, t)
+	EXPECT_EQ(conflict.Variable, 0, t)
+	for _, c := range conflict.Clauses {
+		EXPECT_EQ(c >= 1 && c <= 4, true, t) //子句0与冲突无关
+	}
+
+	//****  与暴力枚举比较  ****
+	NUM := 5
+	seed := 11
+	next := func() int {
+		seed = (seed*1103515245 + 12345) % 2147483648
+		return seed / 65536
+	}
+	for round := 0; round < 200; round++ {
+		problem := NewTwoSAT(NUM)
+		clauses := [][4]int{}
+		for i := 0; i < 8; i++ {
+			c := [4]int{next() % NUM, next() % 2, next() % NUM, next() % 2}
+			clauses = append(clauses, c)
+			problem.AddClause(c[0], c[1] == 1, c[2], c[3] == 1)
+		}
+		satisfied := func(values []bool) bool {
+			for _, c := range clauses {
+				if values[c[0]] != (c[1] == 1) && values[c[2]] != (c[3] == 1) {
+					return false
+				}
+			}
+			return true
+		}
+		brute := false
+		for mask := 0; mask < 1<<uint(NUM); mask++ {
+			values := make([]bool, NUM)
+			for i := 0; i < NUM; i++ {
+				values[i] = mask&(1<<uint(i)) != 0
+			}
+			if satisfied(values) {
+				brute = true
+				break
+			}
+		}
+		values, conflict, _ := problem.Solve()
+		EXPECT_EQ(conflict == nil, brute, t)
+		if brute {
+			EXPECT_EQ(satisfied(values), true, t)
+		} else {
+			//冲突中的子句本身就不可满足
+			core := NewTwoSAT(NUM)
+			for _, i := range conflict.Clauses {
+				c := clauses[i]
+				core.AddClause(c[0], c[1] == 1, c[2], c[3] == 1)
+			}
+			_, core_conflict, _ := core.Solve()
+			EXPECT_EQ(core_conflict != nil, true, t)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * @Description: 介数中心性，Brandes算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ *
+ *
+ * 结点v的介数中心性定义为：
+ *
+ *		CB(v) = sum{ sigma(s,t|v)/sigma(s,t) : s!=v!=t }
+ *
+ * 其中sigma(s,t)为s到t的最短路径的条数，sigma(s,t|v)为其中经过v的条数。
+ *
+ * Brandes算法：对每个源点s求出单源最短路径DAG(记录每个结点的最短路径条数sigma以及最短路径上的前驱列表)，然后按照
+ * 到s的距离从远到近累加依赖值：
+ *
+ *		delta(v) = sum{ sigma(v)/sigma(w) * (1+delta(w)) : v是w的前驱 }
+ *
+ * CB(v)就是所有源点上delta(v)的和。
+ *
+ * 单源最短路径DAG在无权图上用广度优先搜索求出，在带权图上用Dijkstra算法求出。这里需要统计所有最短路径的条数和前驱，
+ * 而且为了能在多个goroutine中并行处理不同的源点，不能修改图的顶点，因此没有复用GraphBFS和Dijkstra，而是在只读的出边表上计算。
+ *
+ * 结果没有归一化；对于每条无向边都存储了两个方向的图，每对结点被计算了两次，需要把结果除以2。
+ *
+ * 性能：无权图时间复杂度O(VE)，带权图O(VE+V^2 lgV)，空间复杂度O(V+E)
+ */
+package Centrality
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 计算所有结点的介数中心性
+ * @param graph: 图；Weighted为true时要求边的权重非负
+ * @return: 每个结点的介数中心性；error
+ *
+ * Workers大于1时，源点被分配给Workers个goroutine并行处理，每个goroutine独立累加，最后求和
+ */
+func (a *Centrality) Betweenness(graph *Graph) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("Betweenness error: graph must not be nil!")
+	}
+	out, err := outEdges(graph, a.Weighted)
+	if err != nil {
+		return nil, err
+	}
+	num := graph.N()
+	sources := make(chan int, num)
+	for s := 0; s < num; s++ {
+		if graph.Vertexes[s] != nil {
+			sources <- s
+		}
+	}
+	close(sources)
+
+	workers := a.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	partial := make([][]float64, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b := newBrandesState(num, out, a.Weighted)
+			for s := range sources {
+				b.accumulate(s)
+			}
+			partial[i] = b.cb
+		}(i)
+	}
+	wg.Wait()
+
+	result := partial[0]
+	for i := 1; i < workers; i++ {
+		for v := range result {
+			result[v] += partial[i][v]
+		}
+	}
+	return result, nil
+}
+
+/**
+ * @description: 单个goroutine的计算状态，各个源点之间复用
+ */
+type brandesState struct {
+	out      [][]edgeTo
+	weighted bool
+	cb       []float64 //累加的介数中心性
+	sigma    []float64 //最短路径条数，用浮点数避免溢出
+	delta    []float64
+	dist     []int
+	preds    [][]int
+	order    []int //按照到源点的距离非降序排列的已确定结点
+}
+
+func newBrandesState(num int, out [][]edgeTo, weighted bool) *brandesState {
+	return &brandesState{
+		out:      out,
+		weighted: weighted,
+		cb:       make([]float64, num),
+		sigma:    make([]float64, num),
+		delta:    make([]float64, num),
+		dist:     make([]int, num),
+		preds:    make([][]int, num),
+	}
+}
+
+/**
+ * @description: 处理源点s，把s的依赖值累加到cb中
+ */
+func (b *brandesState) accumulate(s int) {
+	for v := range b.dist {
+		b.dist[v] = -1
+		b.sigma[v] = 0
+		b.delta[v] = 0
+		b.preds[v] = b.preds[v][:0]
+	}
+	b.order = b.order[:0]
+	b.dist[s] = 0
+	b.sigma[s] = 1
+	if b.weighted {
+		b.dijkstra(s)
+	} else {
+		b.bfs(s)
+	}
+
+	for i := len(b.order) - 1; i >= 0; i-- {
+		w := b.order[i]
+		for _, v := range b.preds[w] {
+			b.delta[v] += b.sigma[v] / b.sigma[w] * (1 + b.delta[w])
+		}
+		if w != s {
+			b.cb[w] += b.delta[w]
+		}
+	}
+}
+
+func (b *brandesState) bfs(s int) {
+	b.order = append(b.order, s)
+	for head := 0; head < len(b.order); head++ {
+		v := b.order[head]
+		for _, e := range b.out[v] {
+			w := e.to
+			if b.dist[w] < 0 {
+				b.dist[w] = b.dist[v] + 1
+				b.order = append(b.order, w)
+			}
+			if b.dist[w] == b.dist[v]+1 {
+				b.sigma[w] += b.sigma[v]
+				b.preds[w] = append(b.preds[w], v)
+			}
+		}
+	}
+}
+
+func (b *brandesState) dijkstra(s int) {
+	settled := make([]bool, len(b.dist))
+	q := &distHeap{{id: s, dist: 0}}
+	for q.Len() > 0 {
+		item := heap.Pop(q).(distItem)
+		v := item.id
+		if settled[v] || item.dist > b.dist[v] { //过期的队列元素
+			continue
+		}
+		settled[v] = true
+		b.order = append(b.order, v)
+		for _, e := range b.out[v] {
+			w := e.to
+			d := b.dist[v] + e.weight
+			if b.dist[w] < 0 || d < b.dist[w] {
+				b.dist[w] = d
+				b.sigma[w] = 0
+				b.preds[w] = b.preds[w][:0]
+				heap.Push(q, distItem{id: w, dist: d})
+			}
+			if d == b.dist[w] && !settled[w] {
+				b.sigma[w] += b.sigma[v]
+				b.preds[w] = append(b.preds[w], v)
+			}
+		}
+	}
+}
+
+type distItem struct {
+	id   int
+	dist int
+}
+
+// 以距离为关键字的二叉最小堆，实现heap.Interface
+type distHeap []distItem
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(distItem)) }
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
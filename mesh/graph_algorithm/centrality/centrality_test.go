@@ -0,0 +1,137 @@
+/*
+ * @Description: 中心性度量测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ */
+package Centrality
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 生成测试用的图，edges中的每一项为{from,to,weight}
+ * @param undirected: 是否同时添加反向边
+ */
+func newCentralityGraph(num int, edges [][]int, undirected bool) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewBFSVertex(key, id)
+	}
+	graph := NewGraph(-1, num, creator) //边的无效权重为-1
+	for i := 0; i < num; i++ {
+		graph.AddVertex(0)
+	}
+	for _, e := range edges {
+		graph.AddEdge(NewTuple(e[0], e[1], e[2]))
+		if undirected {
+			graph.AddEdge(NewTuple(e[1], e[0], e[2]))
+		}
+	}
+	return graph
+}
+
+func expectFloats(actual, expect []float64, t *testing.T) {
+	if len(actual) != len(expect) {
+		t.Errorf("length error: %v, expect %v", actual, expect)
+		return
+	}
+	for i := range actual {
+		if math.Abs(actual[i]-expect[i]) > 1e-6 {
+			t.Errorf("value error: %v, expect %v", actual, expect)
+			return
+		}
+	}
+}
+
+func TestCentrality(t *testing.T) {
+	path := newCentralityGraph(4, [][]int{{0, 1, 2}, {1, 2, 2}, {2, 3, 2}}, true)
+	star := newCentralityGraph(5, [][]int{{0, 1, 1}, {0, 2, 1}, {0, 3, 1}, {0, 4, 1}}, true)
+	//****  0->2有一条长边，也可以经过1绕行  ****
+	detour := newCentralityGraph(3, [][]int{{0, 1, 1}, {1, 2, 1}, {0, 2, 5}}, false)
+	//****  0到3有两条等长的最短路径  ****
+	diamond := newCentralityGraph(4, [][]int{{0, 1, 1}, {1, 3, 1}, {0, 2, 1}, {2, 3, 1}}, false)
+
+	c := NewCentrality()
+	weighted := NewCentrality()
+	weighted.Weighted = true
+
+	//*********** 度中心性 ****************
+	{
+		result, _ := c.Degree(star)
+		expectFloats(result, []float64{1, 0.25, 0.25, 0.25, 0.25}, t)
+		result, _ = c.OutDegree(detour)
+		expectFloats(result, []float64{1, 0.5, 0}, t)
+		result, _ = c.InDegree(detour)
+		expectFloats(result, []float64{0, 0.5, 1}, t)
+	}
+	//*********** 接近中心性 ****************
+	{
+		result, _ := c.Closeness(path)
+		expectFloats(result, []float64{0.5, 0.75, 0.75, 0.5}, t)
+		result, _ = weighted.Closeness(path)
+		expectFloats(result, []float64{0.25, 0.375, 0.375, 0.25}, t)
+		//结点2不能到达任何结点，结点1只能到达结点2
+		result, _ = c.Closeness(detour)
+		expectFloats(result, []float64{1, 0.5, 0}, t)
+	}
+	//*********** 介数中心性 ****************
+	{
+		result, _ := c.Betweenness(path)
+		expectFloats(result, []float64{0, 4, 4, 0}, t)
+		result, _ = c.Betweenness(star)
+		expectFloats(result, []float64{12, 0, 0, 0, 0}, t)
+		result, _ = c.Betweenness(detour)
+		expectFloats(result, []float64{0, 0, 0}, t)
+		result, _ = weighted.Betweenness(detour)
+		expectFloats(result, []float64{0, 1, 0}, t)
+		result, _ = weighted.Betweenness(diamond)
+		expectFloats(result, []float64{0, 0.5, 0.5, 0}, t)
+	}
+	//*********** 并行计算的结果与串行相同 ****************
+	{
+		num := 60
+		edges := [][]int{}
+		seed := 7
+		for i := 0; i < num; i++ {
+			for j := 0; j < num; j++ {
+				seed = (seed*1103515245 + 12345) % 2147483648
+				if i != j && seed%10 == 0 {
+					edges = append(edges, []int{i, j, seed%7 + 1})
+				}
+			}
+		}
+		graph := newCentralityGraph(num, edges, false)
+		for _, cen := range []*Centrality{c, weighted} {
+			serial, _ := cen.Betweenness(graph)
+			cen.Workers = 4
+			parallel, _ := cen.Betweenness(graph)
+			cen.Workers = 0
+			expectFloats(parallel, serial, t)
+		}
+	}
+	//*********** PageRank ****************
+	{
+		result, _ := c.PageRank(star, 0.85, 1e-6)
+		sum := 0.0
+		for _, r := range result {
+			sum += r
+		}
+		EXPECT_EQ(math.Abs(sum-1) < 1e-9, true, t)
+		EXPECT_EQ(result[0] > result[1], true, t)
+
+		//结点1没有出边，PR(0)=0.075+0.425*PR(1)，PR(0)+PR(1)=1
+		single := newCentralityGraph(2, [][]int{{0, 1, 1}}, false)
+		result, _ = c.PageRank(single, 0.85, 1e-9)
+		expectFloats(result, []float64{0.5 / 1.425, 1 - 0.5/1.425}, t)
+
+		_, err := c.PageRank(single, 1.5, 1e-6)
+		EXPECT_EQ(err != nil, true, t)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * @Description: 接近中心性
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ *
+ *
+ * 结点v的接近中心性描述v到其他结点的远近。设从v出发可以到达r个其他结点，到它们的最短距离之和为sum，则
+ *
+ *		C(v) = (r/sum) * (r/(N-1))
+ *
+ * 第一项是v到可达结点的平均距离的倒数；第二项是Wasserman-Faust修正，用可达结点所占的比例进行缩放，使得非连通图中
+ * 只能到达少数几个近邻的结点不会得到过高的值。对于强连通图，第二项为1。不能到达任何结点的v，其接近中心性为0。
+ *
+ * 最短距离的计算复用现有的算法：
+ *
+ * - 无权图(Weighted=false)：对每个结点执行一次广度优先搜索GraphBFS，要求图的顶点类型为BFSVertex
+ * - 带权图(Weighted=true)：对每个结点执行一次Dijkstra算法，要求边的权重非负
+ *
+ * 这两个算法都会修改顶点的`key`、`parent`等属性。
+ */
+package Centrality
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/basic_graph"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/single_source_shortest_path"
+)
+
+/**
+ * @description: 计算所有结点的接近中心性，使用的是从结点出发的出边方向上的距离
+ * @param graph: 图
+ * @return: 每个结点的接近中心性；error
+ *
+ * 性能：无权图时间复杂度O(V(V+E))；带权图为V次Dijkstra的时间
+ */
+func (a *Centrality) Closeness(graph *Graph) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("Closeness error: graph must not be nil!")
+	}
+	num := graph.N()
+	count := vertexCount(graph)
+
+	var distances func(source_id int) ([]int, error)
+	if a.Weighted {
+		for _, edge := range graph.EdgeTuples() {
+			if edge.Third < 0 {
+				return nil, errors.New("Closeness error: edge weight must not be negative!")
+			}
+		}
+		dijkstra := NewDijkstra()
+		distances = func(source_id int) ([]int, error) {
+			if err := dijkstra.ShortestPath(graph, source_id); err != nil {
+				return nil, err
+			}
+			dist := make([]int, num)
+			for v := 0; v < num; v++ {
+				if graph.Vertexes[v] != nil {
+					dist[v] = graph.Vertexes[v].GetKey()
+				}
+			}
+			return dist, nil
+		}
+	} else {
+		for _, v := range graph.Vertexes {
+			if v != nil && ToBFSVertex(v) == nil {
+				return nil, errors.New("Closeness error: vertex must be BFSVertex for unweighted graph!")
+			}
+		}
+		bfs := NewGraphBFS()
+		distances = func(source_id int) ([]int, error) {
+			if err := bfs.Search(graph, source_id, nil, nil); err != nil {
+				return nil, err
+			}
+			dist := make([]int, num)
+			for v := 0; v < num; v++ {
+				if graph.Vertexes[v] != nil {
+					dist[v] = ToBFSVertex(graph.Vertexes[v]).Deep
+				}
+			}
+			return dist, nil
+		}
+	}
+
+	result := make([]float64, num)
+	if count <= 1 {
+		return result, nil
+	}
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] == nil {
+			continue
+		}
+		dist, err := distances(v)
+		if err != nil {
+			return nil, err
+		}
+		reached, sum := 0, 0
+		for w := 0; w < num; w++ {
+			if w == v || graph.Vertexes[w] == nil || Is_Unlimit(dist[w]) {
+				continue
+			}
+			reached++
+			sum += dist[w]
+		}
+		if sum > 0 {
+			r := float64(reached)
+			result[v] = r / float64(sum) * r / float64(count-1)
+		}
+	}
+	return result, nil
+}
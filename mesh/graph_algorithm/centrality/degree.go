@@ -0,0 +1,87 @@
+/*
+ * @Description: 度中心性
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ *
+ *
+ * 结点v的度中心性为 deg(v)/(N-1)，即v直接相连的结点占其他所有结点的比例，取值范围为[0,1]。
+ *
+ * - Degree：把有向边(u,v)视为无向边u--v，deg(v)为v的不同邻居的数目
+ * - InDegree/OutDegree：有向图的入度、出度中心性
+ *
+ * 自环不计入度数。性能：时间复杂度O(V+E)
+ */
+package Centrality
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 无向图的度中心性
+ * @param graph: 图，有向边(u,v)视为无向边u--v
+ * @return: 每个结点的度中心性；error
+ */
+func (a *Centrality) Degree(graph *Graph) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("Degree error: graph must not be nil!")
+	}
+	adj := graph.UndirectedAdjacency()
+	degree := make([]int, graph.N())
+	for v := range adj {
+		degree[v] = len(adj[v])
+	}
+	return a.normalizeDegree(graph, degree), nil
+}
+
+/**
+ * @description: 有向图的入度中心性
+ * @param graph: 图
+ * @return: 每个结点的入度中心性；error
+ */
+func (a *Centrality) InDegree(graph *Graph) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("InDegree error: graph must not be nil!")
+	}
+	degree := make([]int, graph.N())
+	for _, edge := range graph.EdgeTuples() {
+		if edge.First != edge.Second {
+			degree[edge.Second]++
+		}
+	}
+	return a.normalizeDegree(graph, degree), nil
+}
+
+/**
+ * @description: 有向图的出度中心性
+ * @param graph: 图
+ * @return: 每个结点的出度中心性；error
+ */
+func (a *Centrality) OutDegree(graph *Graph) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("OutDegree error: graph must not be nil!")
+	}
+	degree := make([]int, graph.N())
+	for _, edge := range graph.EdgeTuples() {
+		if edge.First != edge.Second {
+			degree[edge.First]++
+		}
+	}
+	return a.normalizeDegree(graph, degree), nil
+}
+
+func (a *Centrality) normalizeDegree(graph *Graph, degree []int) []float64 {
+	result := make([]float64, len(degree))
+	count := vertexCount(graph)
+	if count <= 1 {
+		return result
+	}
+	for v, d := range degree {
+		result[v] = float64(d) / float64(count-1)
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+/*
+ * @Description: 图的中心性度量的公共定义
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ *
+ *
+ * 中心性(centrality)用来衡量图中每个结点的重要程度，常见的度量有：
+ *
+ * - 度中心性：结点的度数除以N-1
+ * - 接近中心性：结点到其他结点的平均最短距离的倒数
+ * - 介数中心性：经过该结点的最短路径所占的比例之和
+ * - PageRank：随机游走在稳态下停留在该结点的概率
+ *
+ * 本包中的所有度量都返回`[]float64`，下标为结点`id`，空结点的值为0；N指的是图中非空结点的数目。
+ */
+package Centrality
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+// PageRank默认的最大迭代次数
+const PAGERANK_MAX_ITERATION = 100
+
+type Centrality struct {
+	Weighted     bool //是否使用边的权重，为false时每条边的长度(或者PageRank中的权重)都视为1
+	Workers      int  //介数中心性并行计算的goroutine数，小于等于1时串行计算
+	MaxIteration int  //PageRank的最大迭代次数
+}
+
+func NewCentrality() *Centrality {
+	return &Centrality{MaxIteration: PAGERANK_MAX_ITERATION}
+}
+
+/**
+ * @description: 统计图中的非空结点数
+ */
+func vertexCount(graph *Graph) int {
+	count := 0
+	for _, v := range graph.Vertexes {
+		if v != nil {
+			count++
+		}
+	}
+	return count
+}
+
+/**
+ * @description: 构建只读的出边表，忽略自环
+ * @param graph: 图
+ * @param weighted: 是否使用边的权重；为false时所有边的权重都为1
+ * @return: out[u]为结点u的出边(v,w)，按照v升序排列；error
+ *
+ * 最短路径相关的度量要求边的权重非负
+ */
+func outEdges(graph *Graph, weighted bool) ([][]edgeTo, error) {
+	num := graph.N()
+	out := make([][]edgeTo, num)
+	for u := 0; u < num; u++ {
+		if graph.Vertexes[u] == nil {
+			continue
+		}
+		edges, _ := graph.VertexEdgeTuples(u)
+		for _, edge := range edges {
+			if edge.Second == u {
+				continue
+			}
+			wt := 1
+			if weighted {
+				if edge.Third < 0 {
+					return nil, errors.New("centrality error: edge weight must not be negative!")
+				}
+				wt = edge.Third
+			}
+			out[u] = append(out[u], edgeTo{to: edge.Second, weight: wt})
+		}
+	}
+	return out, nil
+}
+
+type edgeTo struct {
+	to     int
+	weight int
+}
@@ -0,0 +1,104 @@
+/*
+ * @Description: PageRank
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 14:02:16
+ * @LastEditTime: 2026-10-19 14:02:16
+ * @LastEditors:
+ *
+ *
+ * PageRank把图看成一个随机游走过程：在每一步，游走者以概率d(阻尼系数)沿着当前结点的某条出边走到下一个结点，
+ * 以概率1-d随机跳到任意一个结点。结点的PageRank值就是该过程的稳态分布：
+ *
+ *		PR(v) = (1-d)/N + d * ( sum{ PR(u)*w(u,v)/W(u) : (u,v)属于E } + sum{ PR(u)/N : u没有出边 } )
+ *
+ * 其中W(u)为u所有出边的权重之和；无权时w(u,v)=1，W(u)即为出度。没有出边的结点(悬挂结点)把自己的值平均分给所有结点，自环被忽略。
+ *
+ * 使用幂迭代法求解：从均匀分布出发反复套用上式，直到相邻两次迭代结果的L1距离小于容差tolerance。
+ * 所有结点的PageRank值之和为1。
+ *
+ * 性能：每次迭代的时间复杂度为O(V+E)
+ */
+package Centrality
+
+import (
+	"errors"
+	"math"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 幂迭代法计算PageRank
+ * @param graph: 图；Weighted为true时按照边的权重分配转移概率，此时权重必须为正
+ * @param damping: 阻尼系数，必须在[0,1]之间，通常取0.85
+ * @param tolerance: 收敛容差，必须大于0
+ * @return: 每个结点的PageRank值；超过MaxIteration次迭代仍未收敛时返回error
+ */
+func (a *Centrality) PageRank(graph *Graph, damping, tolerance float64) ([]float64, error) {
+	if graph == nil {
+		return nil, errors.New("PageRank error: graph must not be nil!")
+	}
+	if damping < 0 || damping > 1 {
+		return nil, errors.New("PageRank error: damping must belongs [0,1]!")
+	}
+	if tolerance <= 0 {
+		return nil, errors.New("PageRank error: tolerance must be positive!")
+	}
+
+	num := graph.N()
+	count := vertexCount(graph)
+	rank := make([]float64, num)
+	if count == 0 {
+		return rank, nil
+	}
+
+	//*********** 出边及其转移概率 ****************
+	out, err := outEdges(graph, a.Weighted)
+	if err != nil {
+		return nil, err
+	}
+	total := make([]float64, num)
+	for u := range out {
+		for _, e := range out[u] {
+			if e.weight <= 0 {
+				return nil, errors.New("PageRank error: edge weight must be positive!")
+			}
+			total[u] += float64(e.weight)
+		}
+	}
+
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil {
+			rank[v] = 1 / float64(count)
+		}
+	}
+	next := make([]float64, num)
+	for iter := 0; iter < a.MaxIteration; iter++ {
+		dangling := 0.0
+		for u := 0; u < num; u++ {
+			next[u] = 0
+			if graph.Vertexes[u] != nil && len(out[u]) == 0 {
+				dangling += rank[u]
+			}
+		}
+		for u := range out {
+			for _, e := range out[u] {
+				next[e.to] += damping * rank[u] * float64(e.weight) / total[u]
+			}
+		}
+		base := ((1 - damping) + damping*dangling) / float64(count)
+		diff := 0.0
+		for v := 0; v < num; v++ {
+			if graph.Vertexes[v] == nil {
+				continue
+			}
+			next[v] += base
+			diff += math.Abs(next[v] - rank[v])
+		}
+		rank, next = next, rank
+		if diff < tolerance {
+			return rank, nil
+		}
+	}
+	return nil, errors.New("PageRank error: power iteration failed to converge!")
+}
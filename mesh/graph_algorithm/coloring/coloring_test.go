@@ -0,0 +1,105 @@
+/*
+ * @Description: 图的顶点着色测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 11:05:12
+ * @LastEditTime: 2026-10-19 11:05:12
+ * @LastEditors:
+ */
+package Coloring
+
+import (
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 生成测试用的无向图，每条无向边只添加一个方向
+ */
+func newColoringGraph(num int, edges [][]int) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	graph := NewGraph(-1, num, creator) //边的无效权重为-1
+	for i := 0; i < num; i++ {
+		graph.AddVertex(i)
+	}
+	for _, e := range edges {
+		graph.AddEdge(NewTuple(e[0], e[1], 1))
+	}
+	return graph
+}
+
+func TestGraphColoring(t *testing.T) {
+	//****  5个顶点的环，色数为3  ****
+	cycle := newColoringGraph(5, [][]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}})
+	//****  4个顶点的完全图，色数为4  ****
+	complete := newColoringGraph(4, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}})
+	//****  皇冠图：u_i与v_j(i!=j)相邻。按照u0,v0,u1,v1...的顺序贪心着色需要4种颜色，而它是二分图，色数为2 ****
+	crown := newColoringGraph(8, [][]int{
+		{0, 5}, {0, 7}, {2, 1}, {2, 7}, {4, 1}, {4, 3}, {6, 1}, {6, 3}, {6, 5}, {0, 3}, {2, 5}, {4, 7},
+	})
+
+	greedy := NewGreedyColoring()
+	dsatur := NewDSaturColoring()
+
+	{
+		colors, n, _ := greedy.Color(cycle, nil)
+		EXPECT_EQ(colors, []int{0, 1, 0, 1, 2}, t)
+		EXPECT_EQ(n, 3, t)
+		_, n, _ = greedy.Color(complete, nil)
+		EXPECT_EQ(n, 4, t)
+		colors, n, _ = greedy.Color(crown, nil)
+		EXPECT_EQ(n, 4, t)
+		ok, _ := VerifyColoring(crown, colors)
+		EXPECT_EQ(ok, true, t)
+	}
+	{
+		colors, n, _ := greedy.WelshPowell(cycle)
+		EXPECT_EQ(n, 3, t)
+		ok, _ := VerifyColoring(cycle, colors)
+		EXPECT_EQ(ok, true, t)
+		colors, _, _ = greedy.WelshPowell(crown)
+		ok, _ = VerifyColoring(crown, colors)
+		EXPECT_EQ(ok, true, t)
+	}
+	{
+		colors, n, _ := dsatur.Color(crown)
+		EXPECT_EQ(n, 2, t)
+		ok, _ := VerifyColoring(crown, colors)
+		EXPECT_EQ(ok, true, t)
+		_, n, _ = dsatur.Color(cycle)
+		EXPECT_EQ(n, 3, t)
+	}
+	{
+		_, n, _ := dsatur.ColorExact(cycle)
+		EXPECT_EQ(n, 3, t)
+		_, n, _ = dsatur.ColorExact(complete)
+		EXPECT_EQ(n, 4, t)
+
+		//Petersen图，色数为3
+		petersen := newColoringGraph(10, [][]int{
+			{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+			{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+			{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+		})
+		colors, n, _ := dsatur.ColorExact(petersen)
+		EXPECT_EQ(n, 3, t)
+		ok, _ := VerifyColoring(petersen, colors)
+		EXPECT_EQ(ok, true, t)
+
+		dsatur.MaxExactVertex = 5
+		_, _, err := dsatur.ColorExact(petersen)
+		EXPECT_EQ(err != nil, true, t)
+	}
+	{
+		ok, _ := VerifyColoring(cycle, []int{0, 1, 0, 1, 0})
+		EXPECT_EQ(ok, false, t)
+
+		loop := newColoringGraph(2, [][]int{{0, 0}})
+		_, _, err := greedy.Color(loop, nil)
+		EXPECT_EQ(err != nil, true, t)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * @Description: 顶点着色的DSatur算法，以及基于DSatur的回溯精确算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 11:05:12
+ * @LastEditTime: 2026-10-19 11:05:12
+ * @LastEditors:
+ *
+ *
+ * ## DSatur算法
+ *
+ * 顶点v的饱和度定义为v的邻居中已经使用的不同颜色的数目。DSatur(Brélaz 1979)每一步选取饱和度最大的未着色顶点，
+ * 饱和度相同时选取度数最大的顶点，然后赋予它可用的最小颜色。饱和度高的顶点可选颜色少，优先处理可以减少颜色冲突。
+ * DSatur对二分图、环、轮图等能得到最优解。
+ *
+ * ## 回溯精确算法
+ *
+ * 以DSatur的结果作为初始上界，按照DSatur的顶点选取规则进行深度优先的分支限界搜索：
+ * 对选出的顶点依次尝试所有可用的已有颜色以及一种新颜色；一旦使用的颜色数达到当前最优解就剪枝。
+ * 搜索结束时得到的就是色数以及一个最优着色方案。
+ *
+ * 性能：DSatur时间复杂度O(V^2+E)；精确算法是指数级的，只适用于小图
+ */
+package Coloring
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+// 回溯精确着色默认允许的最大顶点数
+const EXACT_COLORING_MAX_VERTEX = 64
+
+type DSaturColoring struct {
+	MaxExactVertex int //ColorExact允许的最大顶点数，超过则返回错误
+}
+
+func NewDSaturColoring() *DSaturColoring {
+	return &DSaturColoring{MaxExactVertex: EXACT_COLORING_MAX_VERTEX}
+}
+
+/**
+ * @description: 着色过程的状态，记录每个顶点的颜色以及邻居颜色的计数
+ */
+type dsaturState struct {
+	adj       [][]int
+	colors    []int
+	neighbor  []map[int]int //neighbor[v][c]为v的邻居中颜色为c的顶点数
+	uncolored int
+	vertexes  []int //所有非空顶点
+}
+
+func newDSaturState(graph *Graph, adj [][]int) *dsaturState {
+	num := graph.N()
+	s := &dsaturState{adj: adj, colors: make([]int, num), neighbor: make([]map[int]int, num)}
+	for v := 0; v < num; v++ {
+		s.colors[v] = -1
+		s.neighbor[v] = map[int]int{}
+		if graph.Vertexes[v] != nil {
+			s.vertexes = append(s.vertexes, v)
+		}
+	}
+	s.uncolored = len(s.vertexes)
+	return s
+}
+
+/**
+ * @description: 选取饱和度最大的未着色顶点，饱和度相同时选度数最大者，再相同时选`id`最小者
+ */
+func (s *dsaturState) pick() int {
+	best := -1
+	for _, v := range s.vertexes {
+		if s.colors[v] >= 0 {
+			continue
+		}
+		if best < 0 || len(s.neighbor[v]) > len(s.neighbor[best]) ||
+			(len(s.neighbor[v]) == len(s.neighbor[best]) && len(s.adj[v]) > len(s.adj[best])) {
+			best = v
+		}
+	}
+	return best
+}
+
+func (s *dsaturState) assign(v, c int) {
+	s.colors[v] = c
+	s.uncolored--
+	for _, w := range s.adj[v] {
+		s.neighbor[w][c]++
+	}
+}
+
+func (s *dsaturState) unassign(v int) {
+	c := s.colors[v]
+	s.colors[v] = -1
+	s.uncolored++
+	for _, w := range s.adj[v] {
+		s.neighbor[w][c]--
+		if s.neighbor[w][c] == 0 {
+			delete(s.neighbor[w], c)
+		}
+	}
+}
+
+/**
+ * @description: DSatur启发式着色
+ * @param graph: 图
+ * @return: 每个顶点的颜色，使用的颜色数；error
+ */
+func (a *DSaturColoring) Color(graph *Graph) ([]int, int, error) {
+	adj, err := coloringAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+	s := newDSaturState(graph, adj)
+	for s.uncolored > 0 {
+		v := s.pick()
+		c := 0
+		for s.neighbor[v][c] > 0 {
+			c++
+		}
+		s.assign(v, c)
+	}
+	return s.colors, ColorCount(s.colors), nil
+}
+
+/**
+ * @description: 回溯法精确着色
+ * @param graph: 图，非空顶点数不能超过MaxExactVertex
+ * @return: 使用颜色数最少的着色方案，色数；error
+ */
+func (a *DSaturColoring) ColorExact(graph *Graph) ([]int, int, error) {
+	adj, err := coloringAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s := newDSaturState(graph, adj)
+	if len(s.vertexes) > a.MaxExactVertex {
+		return nil, 0, errors.New("ColorExact error: graph is too large for exact coloring!")
+	}
+	//DSatur的结果作为初始上界
+	best_colors, best, _ := a.Color(graph)
+
+	var search func(used int)
+	search = func(used int) {
+		if used >= best {
+			return //剪枝：不可能比当前最优解更好
+		}
+		if s.uncolored == 0 {
+			best = used
+			copy(best_colors, s.colors)
+			return
+		}
+		v := s.pick()
+		//颜色c与颜色c'对称，因此新颜色只需要尝试一种(即used)
+		for c := 0; c <= used && c < best-1; c++ {
+			if s.neighbor[v][c] > 0 {
+				continue
+			}
+			s.assign(v, c)
+			if c == used {
+				search(used + 1)
+			} else {
+				search(used)
+			}
+			s.unassign(v)
+		}
+	}
+	search(0)
+	return best_colors, best, nil
+}
@@ -0,0 +1,136 @@
+/*
+ * @Description: 顶点着色的贪心算法以及Welsh-Powell算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 11:05:12
+ * @LastEditTime: 2026-10-19 11:05:12
+ * @LastEditors:
+ *
+ *
+ * ## 贪心着色
+ *
+ * 按照给定的顺序依次处理顶点，每个顶点选取其邻居没有使用过的最小颜色。若图的最大度数为d，贪心着色最多使用d+1种颜色。
+ * 结果的好坏完全取决于顶点顺序，对于任何图都存在一种顺序使贪心着色得到最优解。
+ *
+ * ## Welsh-Powell算法
+ *
+ * 将顶点按照度数降序排列，然后逐个颜色进行处理：取出第一个未着色的顶点赋予新颜色c，再顺序扫描剩余的未着色顶点，
+ * 凡是与所有颜色为c的顶点都不相邻的顶点也赋予颜色c。重复这一过程直到所有顶点都已着色。
+ * Welsh-Powell最多使用 max{min(d(v_i)+1,i)} 种颜色，其中d(v_i)为第i个顶点(按度数降序)的度数。
+ *
+ * 性能：时间复杂度均为 O(V+E) (不含排序)
+ */
+package Coloring
+
+import (
+	"sort"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type GreedyColoring struct {
+}
+
+func NewGreedyColoring() *GreedyColoring {
+	return &GreedyColoring{}
+}
+
+/**
+ * @description: 按照指定顺序的贪心着色
+ * @param graph: 图
+ * @param order: 顶点的处理顺序，如果为空则按照顶点`id`顺序；不在order中的非空顶点排在最后按`id`顺序处理
+ * @return: 每个顶点的颜色，使用的颜色数；error
+ */
+func (a *GreedyColoring) Color(graph *Graph, order []int) ([]int, int, error) {
+	adj, err := coloringAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+	num := graph.N()
+
+	colors := make([]int, num)
+	for i := 0; i < num; i++ {
+		colors[i] = -1
+	}
+	//used[c]=v 表示在处理顶点v时，颜色c被邻居占用
+	used := make([]int, num+1)
+	for i := range used {
+		used[i] = -1
+	}
+	colorOne := func(v int) {
+		for _, w := range adj[v] {
+			if colors[w] >= 0 {
+				used[colors[w]] = v
+			}
+		}
+		c := 0
+		for used[c] == v {
+			c++
+		}
+		colors[v] = c
+	}
+
+	for _, v := range order {
+		if v >= 0 && v < num && graph.Vertexes[v] != nil && colors[v] < 0 {
+			colorOne(v)
+		}
+	}
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil && colors[v] < 0 {
+			colorOne(v)
+		}
+	}
+	return colors, ColorCount(colors), nil
+}
+
+/**
+ * @description: Welsh-Powell着色
+ * @param graph: 图
+ * @return: 每个顶点的颜色，使用的颜色数；error
+ *
+ * 度数相同的顶点按照`id`升序排列，因此结果是确定的
+ */
+func (a *GreedyColoring) WelshPowell(graph *Graph) ([]int, int, error) {
+	adj, err := coloringAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+	num := graph.N()
+
+	order := []int{}
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil {
+			order = append(order, v)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(adj[order[i]]) > len(adj[order[j]])
+	})
+
+	colors := make([]int, num)
+	for i := 0; i < num; i++ {
+		colors[i] = -1
+	}
+	//blocked[w]=c 表示顶点w与某个颜色为c的顶点相邻
+	blocked := make([]int, num)
+	for i := range blocked {
+		blocked[i] = -1
+	}
+
+	c := 0
+	for start := 0; start < len(order); c++ {
+		for _, v := range order[start:] {
+			if colors[v] >= 0 || blocked[v] == c {
+				continue
+			}
+			colors[v] = c
+			for _, w := range adj[v] {
+				blocked[w] = c
+			}
+		}
+		//跳过已经着色的顶点，找到下一轮的起点
+		for start < len(order) && colors[order[start]] >= 0 {
+			start++
+		}
+	}
+	return colors, c, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * @Description: 图的顶点着色的公共函数
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 11:05:12
+ * @LastEditTime: 2026-10-19 11:05:12
+ * @LastEditors:
+ *
+ *
+ * 图的顶点着色：给无向图G=(V,E)的每个顶点分配一种颜色，使得任意一条边的两个端点颜色不同。所需的最少颜色数称为图的色数。
+ * 求色数是NP难问题，实际应用中(寄存器分配、排课、频率分配等冲突图)通常使用启发式算法，小图可以使用回溯法精确求解。
+ *
+ * 本包中的所有算法都把有向边(u,v)看作无向边u--v，颜色用从0开始的整数表示，空顶点的颜色为-1。
+ */
+package Coloring
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 获取着色用的无向邻接表，同时检查自环
+ * @param graph: 图
+ * @return: 无向邻接表；error
+ *
+ * 带自环的顶点与自己冲突，无法着色
+ */
+func coloringAdjacency(graph *Graph) ([][]int, error) {
+	if graph == nil {
+		return nil, errors.New("coloring error: graph must not be nil!")
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if edge.First == edge.Second {
+			return nil, errors.New("coloring error: graph with self loop can not be colored!")
+		}
+	}
+	return graph.UndirectedAdjacency(), nil
+}
+
+/**
+ * @description: 返回着色方案使用的颜色数
+ * @param colors: 每个顶点的颜色
+ * @return: 颜色数，即最大颜色加1
+ */
+func ColorCount(colors []int) int {
+	count := 0
+	for _, c := range colors {
+		if c+1 > count {
+			count = c + 1
+		}
+	}
+	return count
+}
+
+/**
+ * @description: 检验着色方案是否合法
+ * @param graph: 图
+ * @param colors: 每个顶点的颜色，colors[id]为顶点id的颜色
+ * @return: 合法返回true；如果某条边的两个端点颜色相同，或者某个非空顶点没有着色，则返回false
+ */
+func VerifyColoring(graph *Graph, colors []int) (bool, error) {
+	if graph == nil {
+		return false, errors.New("VerifyColoring error: graph must not be nil!")
+	}
+	num := graph.N()
+	if len(colors) != num {
+		return false, errors.New("VerifyColoring error: len(colors) must equal to graph.N()!")
+	}
+	for i := 0; i < num; i++ {
+		if graph.Vertexes[i] != nil && colors[i] < 0 {
+			return false, nil
+		}
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if colors[edge.First] == colors[edge.Second] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * @Description: 社区发现测试
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 15:10:27
+ * @LastEditTime: 2026-10-19 15:10:27
+ * @LastEditors:
+ */
+package Community
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 生成测试用的邻接表图，edges中的每一项为{u,v,weight}，每条无向边只添加一个方向
+ */
+func newCommunityGraph(num int, edges [][]int) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	graph := NewGraph(0, num, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < num; i++ {
+		graph.AddVertex(0)
+	}
+	for _, e := range edges {
+		graph.AddEdge(NewTuple(e[0], e[1], e[2]))
+	}
+	return graph
+}
+
+/**
+ * @description: 生成有明显社区结构的随机图：groups个社区，每个社区size个结点，
+ * 每个结点向本社区随机连inner条边，每个社区之间随机连outer条边
+ */
+func newPlantedGraph(groups, size, inner, outer int, seed int64) *Graph {
+	rnd := rand.New(rand.NewSource(seed))
+	edges := [][]int{}
+	for g := 0; g < groups; g++ {
+		for i := 0; i < size; i++ {
+			for k := 0; k < inner; k++ {
+				j := rnd.Intn(size)
+				if j != i {
+					edges = append(edges, []int{g*size + i, g*size + j, 1})
+				}
+			}
+		}
+		for k := 0; k < outer; k++ {
+			h := rnd.Intn(groups)
+			if h != g {
+				edges = append(edges, []int{g*size + rnd.Intn(size), h*size + rnd.Intn(size), 1})
+			}
+		}
+	}
+	return newCommunityGraph(groups*size, edges)
+}
+
+func TestCommunity(t *testing.T) {
+	//****  两个5个结点的完全图，由边4--5相连  ****
+	edges := [][]int{{4, 5, 1}}
+	for _, base := range []int{0, 5} {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				edges = append(edges, []int{base + i, base + j, 1})
+			}
+		}
+	}
+	cliques := newCommunityGraph(10, edges)
+	expect := []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1}
+	expect_q := 20.0/21.0 - 0.5
+
+	{
+		q, _ := Modularity(cliques, expect)
+		EXPECT_EQ(math.Abs(q-expect_q) < 1e-9, true, t)
+		q, _ = Modularity(cliques, make([]int, 10))
+		EXPECT_EQ(math.Abs(q) < 1e-9, true, t)
+	}
+	{
+		community, q, _ := NewLabelPropagation(1).Detect(cliques)
+		EXPECT_EQ(community, expect, t)
+		EXPECT_EQ(math.Abs(q-expect_q) < 1e-9, true, t)
+	}
+	{
+		community, q, _ := NewLouvain(1).Detect(cliques)
+		EXPECT_EQ(community, expect, t)
+		EXPECT_EQ(math.Abs(q-expect_q) < 1e-9, true, t)
+	}
+
+	//****  大规模稀疏图：20000个结点，约16万条边  ****
+	planted := newPlantedGraph(200, 100, 8, 20, 3)
+	{
+		c1, q1, _ := NewLouvain(7).Detect(planted)
+		c2, q2, _ := NewLouvain(7).Detect(planted)
+		EXPECT_EQ(c1, c2, t) //相同的种子得到相同的结果
+		EXPECT_EQ(q1, q2, t)
+		EXPECT_EQ(q1 > 0.9, true, t)
+		q, _ := Modularity(planted, c1)
+		EXPECT_EQ(math.Abs(q-q1) < 1e-9, true, t)
+	}
+	{
+		c1, q1, _ := NewLabelPropagation(7).Detect(planted)
+		c2, _, _ := NewLabelPropagation(7).Detect(planted)
+		EXPECT_EQ(c1, c2, t)
+		EXPECT_EQ(q1 > 0.8, true, t)
+	}
+}
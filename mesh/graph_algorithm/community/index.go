@@ -0,0 +1,147 @@
+/*
+ * @Description: 社区发现的公共定义，以及模块度的计算
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 15:10:27
+ * @LastEditTime: 2026-10-19 15:10:27
+ * @LastEditors:
+ *
+ *
+ * 社区发现：把带权无向图G=(V,E)的结点划分为若干个社区，使得社区内部的边尽量稠密、社区之间的边尽量稀疏。
+ * 划分的好坏用模块度(modularity)来衡量：
+ *
+ *		Q = sum{ in(c)/2m - (tot(c)/2m)^2 : c为社区 }
+ *
+ * 其中m为所有边的权重之和，in(c)为两个端点都在社区c中的边的权重之和的2倍，tot(c)为社区c中所有结点的加权度数之和。
+ * Q的取值范围为[-1/2,1)，Q越大说明划分越好。
+ *
+ * 本包把有向边(u,v)看作无向边u--v：如果(u,v)和(v,u)都存在，只计算一次，权重取`id`较小的一端出发的那条边的权重。
+ * 边的权重必须非负，自环被忽略。社区编号为从0开始的连续整数，按照社区中最小的结点`id`排序；空结点的社区编号为-1。
+ *
+ * 稀疏图请使用邻接表表示法(GRAPH_REPRESENTION_ADJ)构造的图，此时所有算法的空间复杂度都是O(V+E)。
+ */
+package Community
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+// 默认的最大迭代次数
+const COMMUNITY_MAX_ITERATION = 100
+
+type weightedEdge struct {
+	to     int
+	weight float64
+}
+
+/**
+ * @description: 构建带权的无向邻接表
+ * @param graph: 图
+ * @return: adj[u]为结点u的所有邻居及边的权重，每条无向边在两个端点中各出现一次；error
+ */
+func weightedAdjacency(graph *Graph) ([][]weightedEdge, error) {
+	if graph == nil {
+		return nil, errors.New("community error: graph must not be nil!")
+	}
+	num := graph.N()
+	adj := make([][]weightedEdge, num)
+	for u := 0; u < num; u++ {
+		if graph.Vertexes[u] == nil {
+			continue
+		}
+		edges, _ := graph.VertexEdgeTuples(u)
+		for _, edge := range edges {
+			v := edge.Second
+			if edge.Third < 0 {
+				return nil, errors.New("community error: edge weight must not be negative!")
+			}
+			if v == u {
+				continue
+			}
+			if v < u { //反向边(v,u)已经存在时，该无向边已经在处理v时加入
+				if has, _ := graph.HasEdge(v, u); has {
+					continue
+				}
+			}
+			adj[u] = append(adj[u], weightedEdge{to: v, weight: float64(edge.Third)})
+			adj[v] = append(adj[v], weightedEdge{to: u, weight: float64(edge.Third)})
+		}
+	}
+	return adj, nil
+}
+
+/**
+ * @description: 计算划分的模块度
+ * @param graph: 图
+ * @param community: 每个结点所属的社区编号，community[id]为结点id的社区；空结点的编号被忽略
+ * @return: 模块度Q；当图中没有边(或者所有边的权重都为0)时返回0；error
+ *
+ * 性能：时间复杂度O(V+E)
+ */
+func Modularity(graph *Graph, community []int) (float64, error) {
+	adj, err := weightedAdjacency(graph)
+	if err != nil {
+		return 0, err
+	}
+	if len(community) != graph.N() {
+		return 0, errors.New("Modularity error: len(community) must equal to graph.N()!")
+	}
+	for v, c := range community {
+		if graph.Vertexes[v] != nil && (c < 0 || c >= len(community)) {
+			return 0, errors.New("Modularity error: community id must belongs [0,N)!")
+		}
+	}
+	return modularity(adj, community), nil
+}
+
+func modularity(adj [][]weightedEdge, community []int) float64 {
+	in := make([]float64, len(adj))
+	tot := make([]float64, len(adj))
+	m2 := 0.0
+	for u := range adj {
+		for _, e := range adj[u] {
+			m2 += e.weight
+			tot[community[u]] += e.weight
+			if community[e.to] == community[u] {
+				in[community[u]] += e.weight
+			}
+		}
+	}
+	if m2 == 0 {
+		return 0
+	}
+	q := 0.0
+	for c := range in {
+		q += in[c]/m2 - (tot[c]/m2)*(tot[c]/m2)
+	}
+	return q
+}
+
+/**
+ * @description: 把任意的社区标签重新编号为从0开始的连续整数，按照社区中最小的结点`id`排序
+ * @param graph: 图
+ * @param label: 每个非空结点的社区标签，标签的取值在[0,N)之间
+ * @return: 重新编号后的社区，空结点为-1
+ */
+func normalizeCommunity(graph *Graph, label []int) []int {
+	num := graph.N()
+	mapping := make([]int, num)
+	for i := range mapping {
+		mapping[i] = -1
+	}
+	result := make([]int, num)
+	count := 0
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] == nil {
+			result[v] = -1
+			continue
+		}
+		if mapping[label[v]] < 0 {
+			mapping[label[v]] = count
+			count++
+		}
+		result[v] = mapping[label[v]]
+	}
+	return result
+}
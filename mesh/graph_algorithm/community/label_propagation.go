@@ -0,0 +1,112 @@
+/*
+ * @Description: 社区发现的标签传播算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 15:10:27
+ * @LastEditTime: 2026-10-19 15:10:27
+ * @LastEditors:
+ *
+ *
+ * 标签传播算法(Raghavan 2007)：
+ *
+ * - 初始时每个结点的标签为它自己的`id`
+ * - 每一轮按照随机顺序处理所有结点：结点v统计邻居中每种标签的边权之和，把自己的标签改为权重和最大的标签；
+ *   有多个最大值时，如果v当前的标签是其中之一则保持不变，否则随机选取一个
+ * - 当一轮中没有任何结点改变标签，或者达到最大迭代次数时结束
+ *
+ * 最终标签相同的结点属于同一个社区。标签传播不直接优化模块度，但是速度很快，每一轮的时间复杂度为O(V+E)。
+ * 随机数由Seed确定，相同的Seed和相同的图总是得到相同的结果。
+ */
+package Community
+
+import (
+	"math/rand"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type LabelPropagation struct {
+	Seed         int64 //随机数种子
+	MaxIteration int   //最大迭代轮数
+}
+
+func NewLabelPropagation(seed int64) *LabelPropagation {
+	return &LabelPropagation{Seed: seed, MaxIteration: COMMUNITY_MAX_ITERATION}
+}
+
+/**
+ * @description: 标签传播社区发现
+ * @param graph: 带权无向图
+ * @return: 每个结点的社区编号，划分的模块度；error
+ */
+func (a *LabelPropagation) Detect(graph *Graph) ([]int, float64, error) {
+	adj, err := weightedAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+	num := graph.N()
+	rnd := rand.New(rand.NewSource(a.Seed))
+
+	label := make([]int, num)
+	order := []int{}
+	for v := 0; v < num; v++ {
+		label[v] = v
+		if graph.Vertexes[v] != nil {
+			order = append(order, v)
+		}
+	}
+
+	weight := make([]float64, num) //weight[l]为当前结点的邻居中标签为l的边权之和
+	stamp := make([]int, num)      //stamp[l]=step 表示标签l在第step次统计中已经出现过
+	touched := []int{}             //本次统计中出现过的标签
+	step := 0
+	best := []int{}
+	for iter := 0; iter < a.MaxIteration; iter++ {
+		rnd.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		changed := false
+		for _, v := range order {
+			if len(adj[v]) == 0 {
+				continue
+			}
+			step++
+			for _, e := range adj[v] {
+				l := label[e.to]
+				if stamp[l] != step {
+					stamp[l] = step
+					touched = append(touched, l)
+				}
+				weight[l] += e.weight
+			}
+			//*********** 找出权重和最大的所有标签 ****************
+			max_weight := -1.0
+			best = best[:0]
+			keep := false
+			for _, l := range touched {
+				if weight[l] > max_weight {
+					max_weight = weight[l]
+					best = append(best[:0], l)
+				} else if weight[l] == max_weight {
+					best = append(best, l)
+				}
+			}
+			for _, l := range best {
+				if l == label[v] {
+					keep = true
+				}
+			}
+			if !keep {
+				label[v] = best[rnd.Intn(len(best))]
+				changed = true
+			}
+			for _, l := range touched {
+				weight[l] = 0
+			}
+			touched = touched[:0]
+		}
+		if !changed {
+			break
+		}
+	}
+
+	community := normalizeCommunity(graph, label)
+	return community, modularity(adj, community), nil
+}
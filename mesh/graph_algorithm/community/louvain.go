@@ -0,0 +1,226 @@
+/*
+ * @Description: 社区发现的Louvain算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 15:10:27
+ * @LastEditTime: 2026-10-19 15:10:27
+ * @LastEditors:
+ *
+ *
+ * Louvain算法(Blondel 2008)是一种贪心地最大化模块度的多层算法，每一层包含两个阶段：
+ *
+ * - 局部移动：初始时每个结点自成一个社区。按照随机顺序处理结点i，把i从它的社区中移出，然后放入使模块度增量最大的邻居社区
+ *   (增量都不为正时放回原社区)。把i放入社区c的模块度增量正比于
+ *
+ *		k_i_in(c) - tot(c)*k_i/2m
+ *
+ *   其中k_i为i的加权度数，k_i_in(c)为i与c中结点之间的边权之和。反复处理所有结点，直到一轮中没有结点移动
+ * - 聚合：把每个社区收缩为一个超级结点，社区之间的边权相加作为超级结点之间的边权，社区内部的边权变为超级结点的自环
+ *
+ * 在聚合后的图上重复以上两个阶段，直到局部移动阶段没有任何结点移动为止。
+ *
+ * 性能：每一轮局部移动的时间复杂度为O(V+E)，层数通常很少，实践中接近线性，适合有数十万条边的稀疏图。
+ * 随机数由Seed确定，相同的Seed和相同的图总是得到相同的结果。
+ */
+package Community
+
+import (
+	"math/rand"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type Louvain struct {
+	Seed         int64 //随机数种子
+	MaxIteration int   //每一层局部移动的最大轮数
+}
+
+func NewLouvain(seed int64) *Louvain {
+	return &Louvain{Seed: seed, MaxIteration: COMMUNITY_MAX_ITERATION}
+}
+
+/**
+ * @description: Louvain的一层：结点之间的边以及结点的自环权重
+ */
+type louvainLevel struct {
+	adj  [][]weightedEdge //不含自环，每条边在两个端点中各出现一次
+	self []float64        //自环的权重
+}
+
+/**
+ * @description: Louvain社区发现
+ * @param graph: 带权无向图
+ * @return: 每个结点的社区编号，划分的模块度；error
+ */
+func (a *Louvain) Detect(graph *Graph) ([]int, float64, error) {
+	adj, err := weightedAdjacency(graph)
+	if err != nil {
+		return nil, 0, err
+	}
+	num := graph.N()
+	rnd := rand.New(rand.NewSource(a.Seed))
+
+	//label[v]为原图结点v在当前层所属的结点
+	label := make([]int, num)
+	for v := 0; v < num; v++ {
+		label[v] = v
+	}
+	level := &louvainLevel{adj: adj, self: make([]float64, num)}
+	for {
+		community, moved := a.moveNodes(level, rnd)
+		if !moved {
+			break
+		}
+		var count int
+		level, count = a.aggregate(level, community)
+		for v := 0; v < num; v++ {
+			label[v] = community[label[v]]
+		}
+		if count == 1 {
+			break
+		}
+	}
+
+	result := normalizeCommunity(graph, label)
+	return result, modularity(adj, result), nil
+}
+
+/**
+ * @description: 局部移动阶段
+ * @param level: 当前层的图
+ * @param rnd: 随机数生成器
+ * @return: 每个结点所属的社区，社区编号为[0,n)之间的整数；是否有结点发生了移动
+ */
+func (a *Louvain) moveNodes(level *louvainLevel, rnd *rand.Rand) ([]int, bool) {
+	n := len(level.adj)
+	community := make([]int, n)
+	degree := make([]float64, n) //加权度数k_i，自环计算两次
+	tot := make([]float64, n)    //tot(c)
+	m2 := 0.0
+	for i := 0; i < n; i++ {
+		community[i] = i
+		degree[i] = 2 * level.self[i]
+		for _, e := range level.adj[i] {
+			degree[i] += e.weight
+		}
+		tot[i] = degree[i]
+		m2 += degree[i]
+	}
+	if m2 == 0 {
+		return community, false
+	}
+
+	order := rnd.Perm(n)
+	weight := make([]float64, n) //weight[c]为当前结点与社区c之间的边权之和
+	stamp := make([]int, n)
+	touched := []int{}
+	step := 0
+	moved := false
+	for iter := 0; iter < a.MaxIteration; iter++ {
+		changed := false
+		for _, i := range order {
+			if len(level.adj[i]) == 0 {
+				continue
+			}
+			step++
+			old := community[i]
+			stamp[old] = step
+			touched = append(touched[:0], old)
+			weight[old] = 0
+			for _, e := range level.adj[i] {
+				c := community[e.to]
+				if stamp[c] != step {
+					stamp[c] = step
+					touched = append(touched, c)
+					weight[c] = 0
+				}
+				weight[c] += e.weight
+			}
+
+			//*********** 把i移出原社区，选取增量最大的社区 ****************
+			tot[old] -= degree[i]
+			best := old
+			best_gain := weight[old] - tot[old]*degree[i]/m2
+			for _, c := range touched {
+				gain := weight[c] - tot[c]*degree[i]/m2
+				if gain > best_gain {
+					best_gain = gain
+					best = c
+				}
+			}
+			tot[best] += degree[i]
+			if best != old {
+				community[i] = best
+				changed = true
+				moved = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	//*********** 把社区重新编号为连续整数 ****************
+	mapping := make([]int, n)
+	for c := range mapping {
+		mapping[c] = -1
+	}
+	count := 0
+	for i := 0; i < n; i++ {
+		if mapping[community[i]] < 0 {
+			mapping[community[i]] = count
+			count++
+		}
+		community[i] = mapping[community[i]]
+	}
+	return community, moved
+}
+
+/**
+ * @description: 聚合阶段，把每个社区收缩为一个结点
+ * @param level: 当前层的图
+ * @param community: 每个结点所属的社区，编号为连续整数
+ * @return: 聚合后的图，以及其结点数
+ */
+func (a *Louvain) aggregate(level *louvainLevel, community []int) (*louvainLevel, int) {
+	count := 0
+	for _, c := range community {
+		if c+1 > count {
+			count = c + 1
+		}
+	}
+	next := &louvainLevel{adj: make([][]weightedEdge, count), self: make([]float64, count)}
+	members := make([][]int, count)
+	for i, c := range community {
+		members[c] = append(members[c], i)
+		next.self[c] += level.self[i]
+	}
+
+	weight := make([]float64, count)
+	stamp := make([]int, count)
+	for c := range stamp {
+		stamp[c] = -1
+	}
+	touched := []int{}
+	for c := 0; c < count; c++ {
+		touched = touched[:0]
+		for _, i := range members[c] {
+			for _, e := range level.adj[i] {
+				d := community[e.to]
+				if d == c {
+					next.self[c] += e.weight / 2 //社区内部的边在两个端点中各出现一次
+					continue
+				}
+				if stamp[d] != c {
+					stamp[d] = c
+					touched = append(touched, d)
+					weight[d] = 0
+				}
+				weight[d] += e.weight
+			}
+		}
+		for _, d := range touched {
+			next.adj[c] = append(next.adj[c], weightedEdge{to: d, weight: weight[d]})
+		}
+	}
+	return next, count
+}
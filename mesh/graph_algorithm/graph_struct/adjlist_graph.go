@@ -99,6 +99,27 @@ func (a *ADJListGraph) AdjustEdge(id1, id2, wt int) error {
 	return nil
 }
 
+/*!
+* @description:删除一条边
+* @param  id1:待删除边的第一个顶点
+* @param  id2:待删除边的第二个顶点
+*
+ */
+func (a *ADJListGraph) RemoveEdge(id1, id2 int) error {
+	if id1 < 0 || id1 >= a._N || id2 < 0 || id2 >= a._N {
+		return errors.New("remove edge params error")
+	}
+
+	vec := a.array[id1]
+	for i, pair := range vec {
+		if pair.First == id2 {
+			a.array[id1] = append(vec[:i], vec[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("edge remove error,edge does not exist.")
+}
+
 /*!
 * @description:返回图中所有边的三元素元组集合
 * @return  :图中所有边的三元素元组集合
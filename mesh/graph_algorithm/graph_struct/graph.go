@@ -149,10 +149,10 @@ func (a *Graph) AddEdge(edge_tuple *Tuple) error {
 		return errors.New("add edge error: vertex of id does not exist.")
 	}
 
-	if wt == a.Matrix.InvalidWeight() {
-		return errors.New("invalid weight")
-	}
 	if a.Matrix != nil {
+		if wt == a.Matrix.InvalidWeight() {
+			return errors.New("invalid weight")
+		}
 		a.Matrix.AddEdge(edge_tuple)
 	} else if a.AdjList != nil {
 		a.AdjList.AddEdge(edge_tuple)
@@ -199,6 +199,30 @@ func (a *Graph) AdjustEdge(id1, id2, wt int) error {
 	return nil
 }
 
+/*!
+* @description:删除一条边
+* @param  id1:待删除边的第一个顶点
+* @param  id2:待删除边的第二个顶点
+* @return error
+*
+ */
+func (a *Graph) RemoveEdge(id1, id2 int) error {
+	if id1 < 0 || id1 >= a._N || id2 < 0 || id2 >= a._N {
+		return errors.New("remove edge error:id must >=0 and <N.")
+	}
+
+	if a.Vertexes[id1] == nil || a.Vertexes[id2] == nil {
+		return errors.New("remove edge error: vertex of id does not exist.")
+	}
+
+	if a.Matrix != nil {
+		return a.Matrix.RemoveEdge(id1, id2)
+	} else if a.AdjList != nil {
+		return a.AdjList.RemoveEdge(id1, id2)
+	}
+	return nil
+}
+
 /*!
 * @description:返回图中所有边的三元素元组集合
 * @return  :图中所有边的三元素元组集合
@@ -299,6 +323,38 @@ func (a *Graph) Weight(id_from, id_to int) (int, error) {
 	return 0, nil
 }
 
+/*!
+* @description:返回图的无向邻接表
+* @return  :无向邻接表，adj[u]为与顶点u相邻的所有顶点的`id`，按照`id`升序排列
+*
+* 将每一条有向边(u,v)都看作无向边u--v：同时存在(u,v)与(v,u)时只算一次，自环被忽略。
+* 无向图通常用一对方向相反的有向边来表示，也可以只添加其中一个方向的边
+*
+* 性能：时间复杂度O(V+ElgE)
+ */
+func (a *Graph) UndirectedAdjacency() [][]int {
+	adj := make([][]int, a._N)
+	for _, edge := range a.EdgeTuples() {
+		if edge.First == edge.Second {
+			continue
+		}
+		adj[edge.First] = append(adj[edge.First], edge.Second)
+		adj[edge.Second] = append(adj[edge.Second], edge.First)
+	}
+	for i, ids := range adj {
+		sort.Ints(ids)
+		//去掉(u,v)与(v,u)同时存在时产生的重复顶点
+		uniq := []int{}
+		for k, id := range ids {
+			if k == 0 || id != ids[k-1] {
+				uniq = append(uniq, id)
+			}
+		}
+		adj[i] = uniq
+	}
+	return adj
+}
+
 /*!
 * @description:返回图的一个翻转镜像
 * @return  :图的一个镜像
@@ -309,9 +365,16 @@ func (a *Graph) Weight(id_from, id_to int) (int, error) {
 * - 图的镜像的边是原图的边的反向
 *
 * 首先新建一个图，再根据原图的顶点来执行顶点的深拷贝。然后再获取原图的边的反向边，将该反向边作为镜像图的边
+*
+* 镜像图沿用原图的表示法，邻接表表示的图翻转之后仍然是邻接表表示
  */
 func (a *Graph) Inverse() *Graph {
-	graph := NewGraph(a.Matrix.invalidWeight, a._N, a.VertexCreator)
+	var graph *Graph = nil
+	if a.Matrix != nil {
+		graph = NewGraph(a.Matrix.invalidWeight, a._N, a.VertexCreator)
+	} else {
+		graph = NewGraph(0, a._N, a.VertexCreator, GRAPH_REPRESENTION_ADJ)
+	}
 
 	vLen := len(a.Vertexes)
 	for i := 0; i < vLen; i++ {
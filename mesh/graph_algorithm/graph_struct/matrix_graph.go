@@ -76,6 +76,27 @@ func (a *MatrixGraph) AdjustEdge(id1, id2, wt int) error {
 	return nil
 }
 
+/*!
+* @description:删除一条边，即把边的权重设为无效权重
+* @param  id1:待删除边的第一个顶点
+* @param  id2:待删除边的第二个顶点
+* @return error
+*
+ */
+func (a *MatrixGraph) RemoveEdge(id1, id2 int) error {
+	if id1 < 0 || id1 >= a._N || id2 < 0 || id2 >= a._N {
+		return errors.New("param is error")
+	}
+
+	b, _ := a.HasEdge(id1, id2)
+	if !b {
+		return errors.New("edge remove error,edge does not exist.")
+	}
+
+	a.Matrix[id1][id2] = a.invalidWeight
+	return nil
+}
+
 /*!
 * @description:返回图中所有边的三元素元组集合
 * @return  :图中所有边的三元素元组集合
@@ -0,0 +1,132 @@
+/*
+ * @Description: 最大流 Dinic算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-20 00:38:52
+ * @LastEditTime: 2026-10-20 00:38:52
+ * @LastEditors:
+ *
+ *
+ * ## Dinic算法
+ *
+ * Dinic算法每个阶段同时沿着多条最短增广路径增广：
+ *
+ * - 层次图：在残余网络中从s出发广度优先搜索，得到每个结点的层次level(v)，即s到v的最短距离。
+ *   只保留满足level(v)=level(u)+1的残余弧(u,v)，得到层次图
+ * - 阻塞流：在层次图中反复用深度优先搜索寻找s到t的路径并增广，直到层次图中不存在s到t的路径。每个结点记录当前弧，
+ *   已经确定无法到达t的弧不再检查
+ *
+ * 每个阶段之后s到t的最短距离至少增加1，因此最多有|V|-1个阶段；每个阶段求阻塞流的时间为O(VE)，所以算法的运行时间为O(V^2 E)。
+ * 在单位容量网络中，运行时间为O(E*min(V^(2/3),E^(1/2)))。
+ */
+package MaxFlow
+
+import (
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type Dinic struct {
+}
+
+func NewDinic() *Dinic {
+	return &Dinic{}
+}
+
+/*!
+ * @description: Dinic算法
+ * @param graph: 流网络，边的权重为容量，必须非负
+ * @param src_id: 流的源点
+ * @param dst_id: 流的汇点
+ * @return: 最大流的值以及每条边上的流，error
+ *
+ * ### 算法步骤
+ *
+ * - 创建残余网络
+ * - 循环：广度优先搜索求出层次图
+ *   - 若dst_id不可达，则当前的流就是最大流
+ *   - 否则重置当前弧，反复深度优先搜索增广，直到找不到增广路径(即得到阻塞流)
+ *
+ * 深度优先搜索用显式的栈实现，避免路径很长时递归过深
+ */
+func (a *Dinic) Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error) {
+	if err := checkFlowNetwork(graph, src_id, dst_id); err != nil {
+		return nil, err
+	}
+	network, err := newResidualNetwork(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	num := graph.N()
+	value := 0
+	level := make([]int, num)
+	current := make([]int, num) //current[u]为u的当前弧在adj[u]中的下标
+	queue := make([]int, 0, num)
+	for {
+		//************* 层次图  ***************
+		for v := range level {
+			level[v] = -1
+		}
+		level[src_id] = 0
+		queue = append(queue[:0], src_id)
+		for i := 0; i < len(queue); i++ {
+			u := queue[i]
+			for _, k := range network.adj[u] {
+				if v := network.to[k]; network.cap[k] > 0 && level[v] < 0 {
+					level[v] = level[u] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+		if level[dst_id] < 0 {
+			break
+		}
+
+		//************* 阻塞流  ***************
+		for u := range current {
+			current[u] = 0
+		}
+		path := []int{} //从src_id出发的弧组成的路径
+		u := src_id
+		for {
+			if u == dst_id {
+				cf := Unlimit()
+				for _, k := range path {
+					if network.cap[k] < cf {
+						cf = network.cap[k]
+					}
+				}
+				for _, k := range path {
+					network.augment(k, cf)
+				}
+				value += cf
+				path = path[:0] //从头开始，饱和的弧会被当前弧跳过
+				u = src_id
+				continue
+			}
+			advanced := false
+			for ; current[u] < len(network.adj[u]); current[u]++ {
+				k := network.adj[u][current[u]]
+				if v := network.to[k]; network.cap[k] > 0 && level[v] == level[u]+1 {
+					path = append(path, k)
+					u = v
+					advanced = true
+					break
+				}
+			}
+			if advanced {
+				continue
+			}
+			//从u无法到达dst_id：把u从层次图中删除，然后回退一步
+			level[u] = -1
+			if u == src_id {
+				break
+			}
+			k := path[len(path)-1]
+			path = path[:len(path)-1]
+			u = network.to[k^1]
+			current[u]++
+		}
+	}
+	return network.result(value), nil
+}
@@ -0,0 +1,89 @@
+/*
+ * @Description: 第26章26.2节 最大流 Edmonds-Karp算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-20 00:25:17
+ * @LastEditTime: 2026-10-20 00:25:17
+ * @LastEditors:
+ *
+ *
+ * ## Edmonds-Karp算法
+ *
+ * Edmonds-Karp算法是用广度优先搜索寻找增广路径的Ford-Fulkerson方法：每次选择残余网络中从s到t的边数最少的增广路径。
+ * 可以证明，每个结点在残余网络中到s的最短距离随着迭代单调递增，每条边成为关键边(残余容量等于增广路径的残余容量)的次数不超过|V|/2，
+ * 因此增广的次数为O(VE)，算法的运行时间为O(VE^2)，与容量的大小无关。
+ *
+ * 与FordFulkerson不同，这里不在每次迭代时重新创建残余网络，而是在残余网络上原地增广。
+ */
+package MaxFlow
+
+import (
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+type EdmondsKarp struct {
+}
+
+func NewEdmondsKarp() *EdmondsKarp {
+	return &EdmondsKarp{}
+}
+
+/*!
+ * @description: Edmonds-Karp算法
+ * @param graph: 流网络，边的权重为容量，必须非负
+ * @param src_id: 流的源点
+ * @param dst_id: 流的汇点
+ * @return: 最大流的值以及每条边上的流，error
+ *
+ * ### 算法步骤
+ *
+ * - 创建残余网络
+ * - 循环：在残余网络中从src_id出发广度优先搜索，记录每个结点的入弧
+ *   - 若dst_id不可达，则不存在增广路径，当前的流就是最大流
+ *   - 否则沿着入弧回溯求出增广路径的残余容量cf(p)，并沿着增广路径增加cf(p)的流量
+ */
+func (a *EdmondsKarp) Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error) {
+	if err := checkFlowNetwork(graph, src_id, dst_id); err != nil {
+		return nil, err
+	}
+	network, err := newResidualNetwork(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	num := graph.N()
+	value := 0
+	arc := make([]int, num) //arc[v]为广度优先树中进入v的弧，-1表示v未被发现
+	queue := make([]int, 0, num)
+	for {
+		for v := range arc {
+			arc[v] = -1
+		}
+		queue = append(queue[:0], src_id)
+		for i := 0; i < len(queue) && arc[dst_id] < 0; i++ {
+			u := queue[i]
+			for _, k := range network.adj[u] {
+				v := network.to[k]
+				if network.cap[k] > 0 && v != src_id && arc[v] < 0 {
+					arc[v] = k
+					queue = append(queue, v)
+				}
+			}
+		}
+		if arc[dst_id] < 0 {
+			break //不存在增广路径
+		}
+
+		cf := Unlimit()
+		for v := dst_id; v != src_id; v = network.to[arc[v]^1] {
+			if network.cap[arc[v]] < cf {
+				cf = network.cap[arc[v]]
+			}
+		}
+		for v := dst_id; v != src_id; v = network.to[arc[v]^1] {
+			network.augment(arc[v], cf)
+		}
+		value += cf
+	}
+	return network.result(value), nil
+}
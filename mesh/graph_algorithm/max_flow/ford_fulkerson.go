@@ -231,3 +231,15 @@ func (a *FordFulkerson) getResidulalNetwork(graph *Graph, flow [][]int) (*Graph,
 	new_graph.AddEdges(new_edges)
 	return new_graph, nil
 }
+
+/**
+ * @description: 实现MaxFlowSolver接口
+ * @return: 最大流的值以及每条边上的流，error
+ */
+func (a *FordFulkerson) Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error) {
+	flow, err := a.MaxFlow(graph, src_id, dst_id)
+	if err != nil {
+		return nil, err
+	}
+	return newFlowResult(graph, src_id, flow), nil
+}
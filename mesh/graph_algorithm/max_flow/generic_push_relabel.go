@@ -451,3 +451,15 @@ func (a *GenericPushRelabel) relabel(graph *Graph, u_id int, flow [][]int) error
 	uvtx.SetHeight(vvtx.GetHeight() + 1)
 	return nil
 }
+
+/**
+ * @description: 实现MaxFlowSolver接口
+ * @return: 最大流的值以及每条边上的流，error
+ */
+func (a *GenericPushRelabel) Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error) {
+	flow, err := a.MaxFlow(graph, src_id, dst_id)
+	if err != nil {
+		return nil, err
+	}
+	return newFlowResult(graph, src_id, flow), nil
+}
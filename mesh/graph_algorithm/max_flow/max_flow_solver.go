@@ -0,0 +1,159 @@
+/*
+ * @Description: 最大流算法的公共接口、结果以及原地更新的残余网络
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-20 00:12:40
+ * @LastEditTime: 2026-10-20 00:12:40
+ * @LastEditors:
+ *
+ *
+ * ## 最大流求解器
+ *
+ * FordFulkerson、GenericPushRelabel、RelabelToFront、EdmondsKarp、Dinic都实现了MaxFlowSolver接口，
+ * Solve返回的FlowResult包含最大流的值以及每条边上的流。
+ *
+ * ## 原地更新的残余网络
+ *
+ * FordFulkerson每次迭代都重新创建一个残余网络Gf。这里用邻接表一次性地创建残余网络：图中的每条边(u,v)对应一对弧，
+ * 下标为2i的弧u-->v的残余容量初始为c(u,v)，下标为2i+1的反向弧v-->u的残余容量初始为0。沿着弧k增加流量x时，
+ * 弧k的残余容量减x，弧k^1的残余容量加x。因此允许图中同时存在边(u,v)和(v,u)。
+ */
+package MaxFlow
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 流网络中的一条边及其上的流
+ */
+type FlowEdge struct {
+	From     int
+	To       int
+	Capacity int //边的容量c(u,v)
+	Flow     int //边上的流f(u,v)
+}
+
+/**
+ * @description: 最大流的结果
+ */
+type FlowResult struct {
+	N     int         //图的结点数目
+	Value int         //最大流的值|f|
+	Edges []*FlowEdge //图中的每条边及其上的流，按照(From,To)升序排列
+}
+
+/**
+ * @description: 以N*N的矩阵表示的流，flow[u][v]为边(u,v)上的流，与MaxFlow的返回值格式相同
+ */
+func (a *FlowResult) Matrix() [][]int {
+	flow := make([][]int, a.N)
+	for i := range flow {
+		flow[i] = make([]int, a.N)
+	}
+	for _, edge := range a.Edges {
+		flow[edge.From][edge.To] += edge.Flow
+	}
+	return flow
+}
+
+/**
+ * @description: 最大流求解器
+ */
+type MaxFlowSolver interface {
+	/**
+	 * @description: 求从src_id到dst_id的最大流
+	 * @param graph: 流网络，边的权重为容量
+	 * @return: 最大流的值以及每条边上的流
+	 */
+	Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error)
+}
+
+/**
+ * @description: 检查最大流的参数
+ */
+func checkFlowNetwork(graph *Graph, src_id, dst_id int) error {
+	if graph == nil {
+		return errors.New("MaxFlow error: graph must not be nil!")
+	}
+	num := graph.N()
+	if src_id < 0 || src_id >= num || graph.Vertexes[src_id] == nil {
+		return errors.New("MaxFlow error: src_id muse belongs [0,N) and src vertex must not be nil!")
+	}
+	if dst_id < 0 || dst_id >= num || graph.Vertexes[dst_id] == nil {
+		return errors.New("MaxFlow error: dst_id muse belongs [0,N) and dst vertex must not be nil!")
+	}
+	if src_id == dst_id {
+		return errors.New("MaxFlow error: src_id must not equal to dst_id!")
+	}
+	return nil
+}
+
+/**
+ * @description: 由MaxFlow返回的流矩阵得到FlowResult
+ */
+func newFlowResult(graph *Graph, src_id int, flow [][]int) *FlowResult {
+	result := &FlowResult{N: graph.N(), Edges: []*FlowEdge{}}
+	for _, edge := range graph.EdgeTuples() {
+		f := flow[edge.First][edge.Second]
+		result.Edges = append(result.Edges, &FlowEdge{From: edge.First, To: edge.Second, Capacity: edge.Third, Flow: f})
+		if edge.First == src_id {
+			result.Value += f
+		}
+		if edge.Second == src_id {
+			result.Value -= f
+		}
+	}
+	return result
+}
+
+/**
+ * @description: 原地更新的残余网络
+ */
+type residualNetwork struct {
+	adj   [][]int //adj[u]为从u出发的弧的下标
+	to    []int   //to[k]为弧k的终点
+	cap   []int   //cap[k]为弧k的残余容量
+	edges []*FlowEdge
+}
+
+/**
+ * @description: 由流网络创建残余网络，初始的流为0
+ * @return: 残余网络；存在容量为负值的边时返回error。自环不影响最大流，被忽略
+ */
+func newResidualNetwork(graph *Graph) (*residualNetwork, error) {
+	a := &residualNetwork{adj: make([][]int, graph.N()), edges: []*FlowEdge{}}
+	for _, edge := range graph.EdgeTuples() {
+		if edge.Third < 0 {
+			return nil, errors.New("MaxFlow error: capacity must not be negative!")
+		}
+		a.edges = append(a.edges, &FlowEdge{From: edge.First, To: edge.Second, Capacity: edge.Third})
+		if edge.First == edge.Second {
+			a.to = append(a.to, edge.Second, edge.First)
+			a.cap = append(a.cap, 0, 0)
+			continue
+		}
+		a.adj[edge.First] = append(a.adj[edge.First], len(a.to))
+		a.adj[edge.Second] = append(a.adj[edge.Second], len(a.to)+1)
+		a.to = append(a.to, edge.Second, edge.First)
+		a.cap = append(a.cap, edge.Third, 0)
+	}
+	return a, nil
+}
+
+// 沿着弧k增加流量x
+func (a *residualNetwork) augment(k, x int) {
+	a.cap[k] -= x
+	a.cap[k^1] += x
+}
+
+/**
+ * @description: 由残余网络得到结果：第i条边上的流为反向弧2i+1的残余容量
+ */
+func (a *residualNetwork) result(value int) *FlowResult {
+	for i, edge := range a.edges {
+		edge.Flow = a.cap[2*i+1]
+	}
+	return &FlowResult{N: len(a.adj), Value: value, Edges: a.edges}
+}
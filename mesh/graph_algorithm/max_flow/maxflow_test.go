@@ -9,6 +9,7 @@ package MaxFlow
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
@@ -138,3 +139,181 @@ func TestRelabelToFront(t *testing.T) {
 	fmt.Println("--   get flow-->", flow)
 
 }
+
+/**
+* 检查结果是一个合法的流：满足容量限制和流量守恒，且流的值正确
+**/
+func checkFlowResult(graph *Graph, result *FlowResult, src_id, dst_id int, t *testing.T) {
+	excess := make([]int, graph.N())
+	EXPECT_EQ(len(result.Edges), len(graph.EdgeTuples()), t)
+	for _, edge := range result.Edges {
+		c, _ := graph.Weight(edge.From, edge.To)
+		EXPECT_EQ(edge.Capacity, c, t)
+		EXPECT_EQ(edge.Flow >= 0 && edge.Flow <= edge.Capacity, true, t)
+		excess[edge.From] -= edge.Flow
+		excess[edge.To] += edge.Flow
+	}
+	for v, e := range excess {
+		switch v {
+		case src_id:
+			EXPECT_EQ(e, -result.Value, t)
+		case dst_id:
+			EXPECT_EQ(e, result.Value, t)
+		default:
+			EXPECT_EQ(e, 0, t)
+		}
+	}
+}
+
+func TestMaxFlowSolver(t *testing.T) {
+	NUM := 6
+	solvers := []struct {
+		solver  MaxFlowSolver
+		creator func(key, id int) IVertex
+	}{
+		{NewFordFulkerson(), func(key, id int) IVertex { return NewVertex(key, id) }},
+		{NewGenericPushRelabel(), func(key, id int) IVertex { return NewFlowVertex(0, key, id) }},
+		{NewRelabelToFront(), func(key, id int) IVertex { return NewFrontFlowVertex(key, id) }},
+		{NewEdmondsKarp(), func(key, id int) IVertex { return NewVertex(key, id) }},
+		{NewDinic(), func(key, id int) IVertex { return NewVertex(key, id) }},
+	}
+	for _, item := range solvers {
+		_graph := NewGraph(0, NUM, item.creator) //边的无效权重为0
+		for i := 0; i < NUM; i++ {
+			_graph.AddVertex(0)
+		}
+		_graph.AddEdges([]*Tuple{NewTuple(0, 1, 16), NewTuple(0, 2, 13), NewTuple(1, 3, 12), NewTuple(2, 1, 4), NewTuple(2, 4, 14),
+			NewTuple(3, 2, 9), NewTuple(3, 5, 20), NewTuple(4, 3, 7), NewTuple(4, 5, 4)})
+		result, err := item.solver.Solve(_graph, 0, 5)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(result.Value, 23, t)
+		checkFlowResult(_graph, result, 0, 5, t)
+		EXPECT_EQ(len(result.Matrix()), NUM, t)
+
+		_, err = item.solver.Solve(nil, 0, 5)
+		EXPECT_EQ(err != nil, true, t)
+	}
+	_, err := NewDinic().Solve(NewGraph(0, 2, func(key, id int) IVertex { return NewVertex(key, id) }), 0, 1)
+	EXPECT_EQ(err != nil, true, t)
+
+	//************* 随机网络：Edmonds-Karp、Dinic与FordFulkerson的最大流的值相同  ***************
+	//FordFulkerson要求不存在反向边，所以只生成i<j的边
+	creator := func(key, id int) IVertex { return NewVertex(key, id) }
+	rnd := rand.New(rand.NewSource(1)) //固定种子，失败时可以复现
+	for round := 0; round < 30; round++ {
+		NUM := 8
+		_graph := NewGraph(0, NUM, creator)
+		for i := 0; i < NUM; i++ {
+			_graph.AddVertex(0)
+		}
+		for i := 0; i < NUM; i++ {
+			for j := i + 1; j < NUM; j++ {
+				if rnd.Intn(2) == 0 {
+					_graph.AddEdge(NewTuple(i, j, rnd.Intn(20)+1))
+				}
+			}
+		}
+		expect, err := NewFordFulkerson().Solve(_graph, 0, NUM-1)
+		EXPECT_EQ(err, nil, t)
+		for _, solver := range []MaxFlowSolver{NewEdmondsKarp(), NewDinic()} {
+			result, err := solver.Solve(_graph, 0, NUM-1)
+			EXPECT_EQ(err, nil, t)
+			EXPECT_EQ(result.Value, expect.Value, t)
+			checkFlowResult(_graph, result, 0, NUM-1, t)
+		}
+	}
+
+	//************* 存在反向边和自环的网络  ***************
+	for round := 0; round < 30; round++ {
+		NUM := 8
+		_graph := NewGraph(0, NUM, creator)
+		for i := 0; i < NUM; i++ {
+			_graph.AddVertex(0)
+		}
+		for i := 0; i < NUM; i++ {
+			for j := 0; j < NUM; j++ {
+				if rnd.Intn(3) == 0 {
+					_graph.AddEdge(NewTuple(i, j, rnd.Intn(20)+1))
+				}
+			}
+		}
+		expect, err := NewEdmondsKarp().Solve(_graph, 0, NUM-1)
+		EXPECT_EQ(err, nil, t)
+		checkFlowResult(_graph, expect, 0, NUM-1, t)
+		result, err := NewDinic().Solve(_graph, 0, NUM-1)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(result.Value, expect.Value, t)
+		checkFlowResult(_graph, result, 0, NUM-1, t)
+	}
+}
+
+/**
+* 最大流最小切割定理：在随机网络上，最大流的值等于最小切割的容量，也等于枚举所有切割得到的最小容量
+**/
+func TestMinCut(t *testing.T) {
+	creator := func(key, id int) IVertex { return NewVertex(key, id) }
+	_graph := NewGraph(0, 6, creator)
+	for i := 0; i < 6; i++ {
+		_graph.AddVertex(0)
+	}
+	_graph.AddEdges([]*Tuple{NewTuple(0, 1, 16), NewTuple(0, 2, 13), NewTuple(1, 3, 12), NewTuple(2, 1, 4), NewTuple(2, 4, 14),
+		NewTuple(3, 2, 9), NewTuple(3, 5, 20), NewTuple(4, 3, 7), NewTuple(4, 5, 4)})
+	cut, err := MinCut(_graph, 0, 5)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(cut.Capacity, 23, t)
+	EXPECT_EQ(cut.SourceSet, []int{0, 1, 2, 4}, t)
+	EXPECT_EQ(cut.SinkSet, []int{3, 5}, t)
+	EXPECT_EQ(len(cut.Edges), 3, t)
+	for i, expect := range []FlowEdge{{1, 3, 12, 12}, {4, 3, 7, 7}, {4, 5, 4, 4}} {
+		EXPECT_EQ(*cut.Edges[i], expect, t)
+	}
+	EXPECT_EQ(cut.InSourceSet(4), true, t)
+	EXPECT_EQ(cut.InSourceSet(3), false, t)
+	_, err = MinCut(_graph, 0, 0)
+	EXPECT_EQ(err != nil, true, t)
+
+	NUM := 7
+	rnd := rand.New(rand.NewSource(1)) //固定种子，失败时可以复现
+	for round := 0; round < 30; round++ {
+		_graph := NewGraph(0, NUM, creator)
+		for i := 0; i < NUM; i++ {
+			_graph.AddVertex(0)
+		}
+		for i := 0; i < NUM; i++ {
+			for j := 0; j < NUM; j++ {
+				if i != j && rnd.Intn(3) == 0 {
+					_graph.AddEdge(NewTuple(i, j, rnd.Intn(20)+1))
+				}
+			}
+		}
+		//枚举所有包含0而不包含NUM-1的集合S
+		expect := -1
+		for mask := 0; mask < 1<<NUM; mask++ {
+			if mask&1 == 0 || mask&(1<<(NUM-1)) != 0 {
+				continue
+			}
+			capacity := 0
+			for _, edge := range _graph.EdgeTuples() {
+				if mask&(1<<edge.First) != 0 && mask&(1<<edge.Second) == 0 {
+					capacity += edge.Third
+				}
+			}
+			if expect < 0 || capacity < expect {
+				expect = capacity
+			}
+		}
+
+		for _, solver := range []MaxFlowSolver{NewEdmondsKarp(), NewDinic()} {
+			cut, err := MinCut(_graph, 0, NUM-1, solver)
+			EXPECT_EQ(err, nil, t)
+			EXPECT_EQ(cut.Capacity, expect, t)
+			EXPECT_EQ(cut.Capacity, cut.Flow.Value, t)
+			EXPECT_EQ(cut.InSourceSet(0), true, t)
+			EXPECT_EQ(cut.InSourceSet(NUM-1), false, t)
+			EXPECT_EQ(len(cut.SourceSet)+len(cut.SinkSet), NUM, t)
+			for _, edge := range cut.Edges {
+				EXPECT_EQ(edge.Flow, edge.Capacity, t)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * @Description: 第26章26.2节 最大流最小切割定理：由最大流求最小切割
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-20 01:02:36
+ * @LastEditTime: 2026-10-20 01:02:36
+ * @LastEditors:
+ *
+ *
+ * ## 最小切割
+ *
+ * 流网络G=(V,E)的一个切割(S,T)将V划分为S和T=V-S，使得s属于S，t属于T。切割的容量c(S,T)为所有从S到T的边的容量之和。
+ * 由最大流最小切割定理，最大流的值等于最小切割的容量。
+ *
+ * 设f为最大流，令S为残余网络Gf中从s可达的结点集合，T=V-S。由于Gf中不存在增广路径，t属于T。对于u属于S、v属于T：
+ *
+ * - 若(u,v)属于E，则f(u,v)=c(u,v)，否则(u,v)属于Ef，v从s可达
+ * - 若(v,u)属于E，则f(v,u)=0，否则(u,v)属于Ef
+ *
+ * 因此|f|=f(S,T)=c(S,T)，(S,T)是一个最小切割，所有从S到T的边都是饱和的。
+ *
+ * 求出最大流之后，广度优先搜索的时间为O(V+E)
+ */
+package MaxFlow
+
+import (
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 最小切割的结果
+ */
+type CutResult struct {
+	SourceSet []int       //S：残余网络中从源点可达的结点，按照`id`升序排列
+	SinkSet   []int       //T=V-S中的结点，按照`id`升序排列
+	Edges     []*FlowEdge //从S到T的边，都是饱和的
+	Capacity  int         //切割的容量c(S,T)，等于最大流的值
+	Flow      *FlowResult //求最小切割所用的最大流
+	inSource  []bool
+}
+
+// 结点v是否属于源点一侧的集合S
+func (a *CutResult) InSourceSet(v int) bool {
+	return v >= 0 && v < len(a.inSource) && a.inSource[v]
+}
+
+/*!
+ * @description: 求从src_id到dst_id的最小切割
+ * @param graph: 流网络，边的权重为容量
+ * @param src_id: 流的源点
+ * @param dst_id: 流的汇点
+ * @param solvers: 可选，求最大流的算法，不指定时使用Dinic
+ * @return: 最小切割，error
+ *
+ * ### 算法步骤
+ *
+ * - 用solver求出最大流f
+ * - 由f得到残余网络：若f(u,v)<c(u,v)则存在残余边(u,v)；若f(u,v)>0则存在残余边(v,u)
+ * - 从src_id出发广度优先搜索得到S，从S到T的边就是切割边
+ */
+func MinCut(graph *Graph, src_id, dst_id int, solvers ...MaxFlowSolver) (*CutResult, error) {
+	if err := checkFlowNetwork(graph, src_id, dst_id); err != nil {
+		return nil, err
+	}
+	var solver MaxFlowSolver = NewDinic()
+	if len(solvers) > 0 && solvers[0] != nil {
+		solver = solvers[0]
+	}
+	flow, err := solver.Solve(graph, src_id, dst_id)
+	if err != nil {
+		return nil, err
+	}
+
+	num := graph.N()
+	residual := make([][]int, num)
+	for _, edge := range flow.Edges {
+		if edge.Flow < edge.Capacity {
+			residual[edge.From] = append(residual[edge.From], edge.To)
+		}
+		if edge.Flow > 0 {
+			residual[edge.To] = append(residual[edge.To], edge.From)
+		}
+	}
+	result := &CutResult{SourceSet: []int{}, SinkSet: []int{}, Edges: []*FlowEdge{}, Flow: flow, inSource: make([]bool, num)}
+	result.inSource[src_id] = true
+	queue := []int{src_id}
+	for i := 0; i < len(queue); i++ {
+		for _, v := range residual[queue[i]] {
+			if !result.inSource[v] {
+				result.inSource[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] == nil {
+			continue
+		}
+		if result.inSource[v] {
+			result.SourceSet = append(result.SourceSet, v)
+		} else {
+			result.SinkSet = append(result.SinkSet, v)
+		}
+	}
+	for _, edge := range flow.Edges {
+		if result.inSource[edge.From] && !result.inSource[edge.To] {
+			result.Edges = append(result.Edges, edge)
+			result.Capacity += edge.Capacity
+		}
+	}
+	return result, nil
+}
@@ -254,3 +254,15 @@ func (a *RelabelToFront) MaxFlow(graph *Graph, src_id, dst_id int) ([][]int, err
 	}
 	return flow, nil
 }
+
+/**
+ * @description: 实现MaxFlowSolver接口
+ * @return: 最大流的值以及每条边上的流，error
+ */
+func (a *RelabelToFront) Solve(graph *Graph, src_id, dst_id int) (*FlowResult, error) {
+	flow, err := a.MaxFlow(graph, src_id, dst_id)
+	if err != nil {
+		return nil, err
+	}
+	return newFlowResult(graph, src_id, flow), nil
+}
@@ -90,10 +90,11 @@ func (a *KruskalMST) Generate(graph *Graph, pre_action, post_action KruskalMSTAc
 	edges := graph.EdgeTuples()
 	new_edges := []*Tuple{}
 
-	//需要将边按照权重排序
-
-	sorter := NewTupleWapper(edges, TupleCompareFunc_Less)
-	sort.Sort(sorter)
+	//需要将边按照权重排序。原来用TupleCompareFunc_Less排序，它按照(First,Second,Third)比较，实际上是按照端点排序，
+	//得到的不一定是最小生成树，这里修正为按照权重排序。EdgeTuples已经按照(from,to)排好序，稳定排序保证权重相同的边的处理顺序是确定的
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Third < edges[j].Third
+	})
 
 	for _, edge := range edges {
 		from_id := edge.First
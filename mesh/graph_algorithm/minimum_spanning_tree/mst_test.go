@@ -9,12 +9,14 @@ package MinimumSpanningTree
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
 	"testing"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/common"
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
 )
 
 func TestKruskal(t *testing.T) {
@@ -243,3 +245,96 @@ func TestPrim(t *testing.T) {
 
 	}
 }
+
+/**
+ * @description:不同优先队列的Prim算法得到的最小生成树权重一致，且生成树包含所有结点
+ */
+func TestPrimQueue(t *testing.T) {
+	P_NUM := 200
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(1))
+	graph := NewGraph(-1, P_NUM, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < P_NUM; i++ {
+		graph.AddVertex(0)
+	}
+	for i := 0; i < P_NUM; i++ {
+		for j := 0; j < 3; j++ {
+			v, w := r.Intn(P_NUM), 1+r.Intn(100)
+			if has, _ := graph.HasEdge(i, v); v != i && !has {
+				graph.AddEdge(NewTuple(i, v, w)) //无向图
+				graph.AddEdge(NewTuple(v, i, w))
+			}
+		}
+		if has, _ := graph.HasEdge(i-1, i); i > 0 && !has {
+			graph.AddEdge(NewTuple(i-1, i, 100)) //保证连通
+			graph.AddEdge(NewTuple(i, i-1, 100))
+		}
+	}
+
+	expect, _, _ := NewPrimMST().Generate(graph, 0, nil, nil)
+	creators := []PriorityQueueCreator{NewBinaryHeapQueue, NewFibonacciQueue, NewPairingHeapQueue, NewLazyHeapQueue}
+	for _, queue_creator := range creators {
+		weight, edges, _ := NewPrimMST(queue_creator).Generate(graph, 0, nil, nil)
+		EXPECT_EQ(weight, expect, t)
+		EXPECT_EQ(len(edges), P_NUM-1, t)
+		sum := 0
+		for _, edge := range edges {
+			sum += edge.Third
+		}
+		EXPECT_EQ(sum, weight, t)
+	}
+}
+
+/**
+ * @description:Kruskal算法必须按照权重处理边。原来按照(from,to)排序，下面的三角形会先选中权重为10的边0--1
+ */
+func TestKruskalWeightOrder(t *testing.T) {
+	set_creator := func(key, id int) IVertex {
+		return NewSetVertex(key, id)
+	}
+	triangle := NewGraph(-1, 3, set_creator)
+	for i := 0; i < 3; i++ {
+		triangle.AddVertex(0)
+	}
+	triangle.AddEdges([]*Tuple{NewTuple(0, 1, 10), NewTuple(0, 2, 1), NewTuple(1, 2, 1)})
+	weight, edges, err := NewKruskalMST().Generate(triangle, nil, nil)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(weight, 2, t)
+	EXPECT_EQ(len(edges), 2, t)
+	for _, edge := range edges {
+		EXPECT_EQ(edge.Third, 1, t)
+	}
+
+	//**********  随机的连通无向图：Kruskal与Prim得到的最小生成树权重相同  ***************
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(3))
+	NUM := 50
+	for round := 0; round < 10; round++ {
+		kruskal_graph := NewGraph(-1, NUM, set_creator, GRAPH_REPRESENTION_ADJ)
+		prim_graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < NUM; i++ {
+			kruskal_graph.AddVertex(0)
+			prim_graph.AddVertex(0)
+		}
+		for i := 1; i < NUM; i++ {
+			for _, u := range []int{r.Intn(i), r.Intn(i)} { //每个结点至少与一个编号更小的结点相连，保证连通
+				if has, _ := prim_graph.HasEdge(u, i); has {
+					continue
+				}
+				w := 1 + r.Intn(100)
+				kruskal_graph.AddEdge(NewTuple(u, i, w))
+				prim_graph.AddEdge(NewTuple(u, i, w))
+				prim_graph.AddEdge(NewTuple(i, u, w))
+			}
+		}
+		expect, _, _ := NewPrimMST().Generate(prim_graph, 0, nil, nil)
+		weight, edges, err := NewKruskalMST().Generate(kruskal_graph, nil, nil)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(weight, expect, t)
+		EXPECT_EQ(len(edges), NUM-1, t)
+	}
+}
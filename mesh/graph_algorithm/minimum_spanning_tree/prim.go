@@ -38,10 +38,20 @@ import (
 )
 
 type PrimMST struct {
+	creator PriorityQueueCreator //优先队列的创建函数，为nil时使用MinQueue
 }
 
-func NewPrimMST() *PrimMST {
-	return &PrimMST{}
+/**
+ * @description: 创建Prim算法
+ * @param creators: 可选，优先队列的创建函数，例如NewBinaryHeapQueue、NewFibonacciQueue、NewPairingHeapQueue、NewLazyHeapQueue。
+ *		不指定时使用MinQueue
+ */
+func NewPrimMST(creators ...PriorityQueueCreator) *PrimMST {
+	a := &PrimMST{}
+	if len(creators) > 0 {
+		a.creator = creators[0]
+	}
+	return a
 }
 
 type PrimMSTActionFunc func(id int)
@@ -72,6 +82,9 @@ type PrimMSTActionFunc func(id int)
 * ### 算法性能
 *
 * Prim总时间代价为O(VlgV+ElgV)=O(ElgV)(使用最小堆实现的最小优先级队列），或者O(E+VlgV)（使用斐波那契堆实现最小优先级队列）
+*
+* 使用MinQueue时，由于`ElementIndex()`是线性查找，时间代价为O(V^2+VE)。指定了IndexedPriorityQueue时，队列中只存放已经发现的结点，
+* 返回的边为最小生成树的边(v.pai,v)，按照结点加入树的顺序排列；从`source_id`不可达的结点不加入树中。
  */
 func (a *PrimMST) Generate(graph *Graph, source_id int, pre_action, post_action PrimMSTActionFunc) (int, []*Tuple, error) {
 
@@ -86,6 +99,10 @@ func (a *PrimMST) Generate(graph *Graph, source_id int, pre_action, post_action
 		return -1, nil, errors.New("prim error: source_id is not in limit!")
 	}
 
+	if a.creator != nil {
+		return a.generateWithQueue(graph, source_id, pre_action, post_action)
+	}
+
 	//最小优先队列
 	q := NewMinQueue(NodeCompareFunc_VertexLessThan, nil)
 	for i := 0; i < num; i++ {
@@ -108,7 +125,7 @@ func (a *PrimMST) Generate(graph *Graph, source_id int, pre_action, post_action
 	for !q.IsEmpty() {
 
 		u, _ := q.ExtractMin()
-		minNode, ok := u.(*Vertex)
+		minNode, ok := u.(IVertex)
 		if !ok || minNode == nil {
 			continue
 		}
@@ -116,7 +133,7 @@ func (a *PrimMST) Generate(graph *Graph, source_id int, pre_action, post_action
 		if pre_action != nil {
 			pre_action(minNode.GetID())
 		}
-		edges, _ := graph.VertexEdgeTuples(ToInt(minNode.GetKey())) //graph.EdgeTuples()
+		edges, _ := graph.VertexEdgeTuples(minNode.GetID())
 		for _, edge := range edges {
 			other_id := edge.Second
 			other_vtx := graph.Vertexes[other_id]
@@ -141,3 +158,56 @@ func (a *PrimMST) Generate(graph *Graph, source_id int, pre_action, post_action
 
 	return weight, ret_edges, nil
 }
+
+/**
+ * @description: 基于IndexedPriorityQueue的Prim算法
+ *
+ * 队列中的元素为结点`id`，关键字为连接该结点与树的最小边的权重；in_edge[id]记录这条边，结点弹出时把它加入最小生成树。
+ */
+func (a *PrimMST) generateWithQueue(graph *Graph, source_id int, pre_action, post_action PrimMSTActionFunc) (int, []*Tuple, error) {
+	num := graph.N()
+	in_tree := make([]bool, num)
+	in_edge := make([]*Tuple, num)
+	for i := 0; i < num; i++ {
+		vertex := graph.Vertexes[i]
+		if vertex != nil {
+			vertex.SetParent(nil)
+			vertex.SetKey(Unlimit())
+		}
+	}
+	graph.Vertexes[source_id].SetKey(0)
+
+	weight := 0
+	ret_edges := []*Tuple{}
+	q := a.creator(num)
+	q.Push(source_id, 0)
+	for q.Len() > 0 {
+		id, key := q.Pop()
+		in_tree[id] = true
+		minNode := graph.Vertexes[id]
+		if pre_action != nil {
+			pre_action(id)
+		}
+		if in_edge[id] != nil {
+			minNode.SetParent(graph.Vertexes[in_edge[id].First])
+			ret_edges = append(ret_edges, in_edge[id])
+		}
+
+		edges, _ := graph.VertexEdgeTuples(id)
+		for _, edge := range edges {
+			other_id := edge.Second
+			other_vtx := graph.Vertexes[other_id]
+			if !in_tree[other_id] && edge.Third < other_vtx.GetKey() {
+				other_vtx.SetKey(edge.Third)
+				in_edge[other_id] = edge
+				q.Push(other_id, edge.Third)
+			}
+		}
+
+		if post_action != nil {
+			post_action(id)
+		}
+		weight += key
+	}
+	return weight, ret_edges, nil
+}
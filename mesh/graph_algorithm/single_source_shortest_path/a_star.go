@@ -0,0 +1,263 @@
+/*
+ * @Description: A*搜索，带启发函数的点到点最短路径
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 18:20:41
+ * @LastEditTime: 2026-10-19 18:20:41
+ * @LastEditors:
+ *
+ *
+ * ## A*算法
+ *
+ * Dijkstra算法按照d(s,u)从小到大的顺序扩展结点，在点到点的最短路径问题中会扩展大量远离目标的结点。A*算法为每个结点u提供一个启发函数h(u)，
+ * 它是u到目标t的最短路径权重的估计值，然后按照f(u)=g(u)+h(u)从小到大的顺序扩展结点，其中g(u)为当前已知的s到u的最短路径估计值。
+ *
+ * - 若h是可接受的(admissible)，即对所有结点u都有 h(u)<=delt(u,t)，则目标t第一次被扩展时，g(t)就是最短路径权重
+ * - 若h还是一致的(consistent)，即对所有边(u,v)都有 h(u)<=w(u,v)+h(v)，则每个结点最多被扩展一次
+ * - h=0时A*算法退化为Dijkstra算法
+ *
+ * 本实现只要求h是可接受的：已经扩展过的结点如果找到了更短的路径，会被重新放入队列(reopen)。因此对于一致的启发函数，扩展次数不超过V。
+ *
+ * A*算法既可以在Graph上运行，也可以在隐式图(例如网格)上运行：隐式图不需要事先构造所有的顶点和边，只需要给出每个结点的出边。
+ *
+ * 时间复杂度：最坏情况与Dijkstra算法相同，为O((V+E)lgV)；启发函数越准确，扩展的结点越少。
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
+)
+
+/**
+ * @description: 启发函数，返回结点`id`到目标结点的最短路径权重的估计值，必须是非负的且不超过真实值
+ */
+type AStarHeuristicFunc func(id int) int
+
+/**
+ * @description: A*算法使用的隐式图，结点`id`属于[0,N())
+ */
+type AStarGraph interface {
+	N() int                                           //结点`id`的上界
+	Neighbors(id int, action func(to_id, weight int)) //对结点id的每条出边(id,to_id)调用一次action，权重必须非负
+}
+
+/**
+ * @description: A*搜索的结果
+ */
+type AStarResult struct {
+	Cost     int   //最短路径的权重
+	Path     []int //最短路径上的结点`id`，从源结点到目标结点
+	Expanded int   //扩展(从优先队列中弹出)的结点次数
+}
+
+type AStar struct {
+	creator PriorityQueueCreator
+}
+
+/**
+ * @description: 创建A*算法
+ * @param creators: 可选，优先队列的创建函数，不指定时使用NewBinaryHeapQueue
+ */
+func NewAStar(creators ...PriorityQueueCreator) *AStar {
+	a := &AStar{creator: NewBinaryHeapQueue}
+	if len(creators) > 0 && creators[0] != nil {
+		a.creator = creators[0]
+	}
+	return a
+}
+
+/*!
+ * @description: 在隐式图上搜索从source_id到target_id的最短路径
+ * @param graph: 隐式图
+ * @param source_id: 源结点`id`
+ * @param target_id: 目标结点`id`
+ * @param heuristic: 启发函数，为nil时退化为Dijkstra算法
+ * @return: 搜索结果；目标不可达时返回*UnreachableError，存在负权重边时返回error
+ *
+ * ### 算法步骤
+ *
+ * - 令g(s)=0，把s以关键字h(s)放入优先队列
+ * - 循环直到队列为空：
+ *   - 弹出f值最小的结点u，如果u就是目标结点，则沿着前驱结点构造路径并返回
+ *   - 对u的每条出边(u,v)，若g(u)+w(u,v)<g(v)，则更新g(v)和v的前驱，并把v以关键字g(v)+h(v)放入队列
+ */
+func (a *AStar) Search(graph AStarGraph, source_id, target_id int, heuristic AStarHeuristicFunc) (*AStarResult, error) {
+	if graph == nil {
+		return nil, errors.New("AStar error: graph must not be nil!")
+	}
+	num := graph.N()
+	if source_id < 0 || source_id >= num || target_id < 0 || target_id >= num {
+		return nil, errors.New("AStar error: source_id and target_id must belongs [0,N)!")
+	}
+	if heuristic == nil {
+		heuristic = func(id int) int { return 0 }
+	}
+
+	unlimit := Unlimit()
+	g := make([]int, num)
+	parent := make([]int, num)
+	for i := 0; i < num; i++ {
+		g[i] = unlimit
+		parent[i] = -1
+	}
+	g[source_id] = 0
+
+	result := &AStarResult{}
+	var err error
+	q := a.creator(num)
+	q.Push(source_id, heuristic(source_id))
+	for q.Len() > 0 {
+		u, _ := q.Pop()
+		result.Expanded++
+		if u == target_id {
+			result.Cost = g[u]
+			for v := u; v >= 0; v = parent[v] {
+				result.Path = append(result.Path, v)
+			}
+			Revert(result.Path)
+			return result, nil
+		}
+
+		graph.Neighbors(u, func(v, weight int) {
+			if weight < 0 {
+				err = errors.New("AStar error: edge weight must not be negative!")
+				return
+			}
+			//饱和加法：和为正无穷时v仍然不可达
+			if sum := AddDistance(g[u], weight); !Is_Unlimit(sum) && sum < g[v] {
+				g[v] = sum
+				parent[v] = u
+				q.Push(v, AddDistance(sum, heuristic(v)))
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, &UnreachableError{Source: source_id, Target: target_id}
+}
+
+/**
+ * @description: 在Graph上搜索从source_id到target_id的最短路径，参数与返回值同Search。不修改顶点的key和父结点
+ */
+func (a *AStar) SearchGraph(graph *Graph, source_id, target_id int, heuristic AStarHeuristicFunc) (*AStarResult, error) {
+	if graph == nil {
+		return nil, errors.New("AStar error: graph must not be nil!")
+	}
+	if source_id < 0 || source_id >= graph.N() || graph.Vertexes[source_id] == nil ||
+		target_id < 0 || target_id >= graph.N() || graph.Vertexes[target_id] == nil {
+		return nil, errors.New("AStar error: source vertex and target vertex must not be nil!")
+	}
+	return a.Search(&aStarGraphAdapter{graph: graph}, source_id, target_id, heuristic)
+}
+
+// 把Graph适配为AStarGraph
+type aStarGraphAdapter struct {
+	graph *Graph
+}
+
+func (a *aStarGraphAdapter) N() int {
+	return a.graph.N()
+}
+
+func (a *aStarGraphAdapter) Neighbors(id int, action func(to_id, weight int)) {
+	edges, _ := a.graph.VertexEdgeTuples(id)
+	for _, edge := range edges {
+		action(edge.Second, edge.Third)
+	}
+}
+
+/**
+ * @description: 四连通的网格图，是一种隐式图。格子(x,y)的结点`id`为 y*Width+x
+ *
+ * 从一个格子移动到上下左右相邻的格子，代价为进入的那个格子的代价；被阻挡的格子不可进入。
+ */
+type GridGraph struct {
+	Width, Height int
+	blocked       []bool
+	costs         []int //costs[id]为进入格子id的代价，默认为1
+	min_cost      int   //所有格子代价的最小值
+}
+
+func NewGridGraph(width, height int) *GridGraph {
+	costs := make([]int, width*height)
+	for i := range costs {
+		costs[i] = 1
+	}
+	return &GridGraph{Width: width, Height: height, blocked: make([]bool, width*height), costs: costs, min_cost: 1}
+}
+
+func (a *GridGraph) N() int {
+	return a.Width * a.Height
+}
+
+// 格子(x,y)的结点`id`
+func (a *GridGraph) ID(x, y int) int {
+	return y*a.Width + x
+}
+
+// 结点`id`对应的格子坐标
+func (a *GridGraph) XY(id int) (int, int) {
+	return id % a.Width, id / a.Width
+}
+
+func (a *GridGraph) SetBlocked(x, y int, blocked bool) {
+	a.blocked[a.ID(x, y)] = blocked
+}
+
+func (a *GridGraph) IsBlocked(x, y int) bool {
+	return a.blocked[a.ID(x, y)]
+}
+
+/**
+ * @description: 设置进入格子(x,y)的代价
+ * @return: 代价为负时返回error
+ */
+func (a *GridGraph) SetCost(x, y, cost int) error {
+	if cost < 0 {
+		return errors.New("SetCost error: cost must not be negative!")
+	}
+	a.costs[a.ID(x, y)] = cost
+	a.min_cost = cost
+	for _, c := range a.costs {
+		if c < a.min_cost {
+			a.min_cost = c
+		}
+	}
+	return nil
+}
+
+func (a *GridGraph) Neighbors(id int, action func(to_id, weight int)) {
+	x, y := a.XY(id)
+	for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		nx, ny := x+d[0], y+d[1]
+		if nx < 0 || nx >= a.Width || ny < 0 || ny >= a.Height {
+			continue
+		}
+		to_id := a.ID(nx, ny)
+		if !a.blocked[to_id] {
+			action(to_id, a.costs[to_id])
+		}
+	}
+}
+
+/**
+ * @description: 到目标格子的曼哈顿距离乘以格子的最小代价，对于四连通网格是一致的启发函数
+ */
+func (a *GridGraph) ManhattanHeuristic(target_id int) AStarHeuristicFunc {
+	tx, ty := a.XY(target_id)
+	return func(id int) int {
+		x, y := a.XY(id)
+		dx, dy := x-tx, y-ty
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return (dx + dy) * a.min_cost
+	}
+}
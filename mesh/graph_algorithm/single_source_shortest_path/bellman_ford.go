@@ -58,13 +58,15 @@ func NewBellmanFordShortestPath() *BellmanFordShortestPath {
 * @description:单源最短路径的bellman ford算法
 * @param graph:图
 * @param source_id：最小生成树的根结点`id`
-* @return: 是否不包含可以从源结点可达的权重为负值的环路。若返回值为true，则说明不包含可以从源结点可达的权重为负值的环路
+* @return: 是否不包含可以从源结点可达的权重为负值的环路。若返回值为true，则说明不包含可以从源结点可达的权重为负值的环路；
+*		否则error为*NegativeCycleError，包含其中一个权重为负值的环路
 *
 * ### 算法步骤
 *
 * - 执行单源最短路径的初始化过程
 * - 进行|V|-1次处理，每次处理过程为：对图的每一条边进行一次松弛操作
-* - 检查图中是否存在权重为负的环路并返回与之相适应的布尔值
+* - 检查图中是否存在权重为负的环路并返回与之相适应的布尔值。如果第V次处理中仍有结点x被松弛，则从x出发沿着父结点回溯V次，
+* 得到的结点一定位于前驱子图的一个环路上，这个环路的权重为负值
 *
 *
 * ### 算法性能
@@ -102,17 +104,87 @@ func (a *BellmanFordShortestPath) ShortestPath(graph *Graph, source_id int) (boo
 		}
 	}
 	//**********  第二阶段 检验是否存在从源点可达的【权重为负的环路】 *************
+	//第V次处理中仍然能被松弛的结点x，沿着父结点回溯V次之后一定位于某个权重为负值的环路上
+	last_relaxed := -1
 	for _, edge := range graph.EdgeTuples() {
 		v1 := graph.Vertexes[edge.First]
 		v2 := graph.Vertexes[edge.Second]
 		wt := edge.Third
-		if v2.GetKey() > v1.GetKey()+wt {
-			return false, nil
+		sum := AddDistance(v1.GetKey(), wt)
+		if Is_Unlimit(sum) { //v1不可达
+			continue
 		}
+		if v1 == v2 {
+			if wt < 0 { //权重为负值的自环
+				return false, &NegativeCycleError{Cycle: []int{edge.First}, Weight: wt}
+			}
+			continue
+		}
+		//最短路径权重达到负无穷(-Unlimit())之后不会再减小，也说明存在权重为负值的环路或者超出了int的表示范围
+		if v2.GetKey() > sum || sum == -Unlimit() && wt <= 0 {
+			a.relax(v1, v2, wt)
+			last_relaxed = edge.Second
+		}
+	}
+	if last_relaxed >= 0 {
+		return false, a.negativeCycle(graph, last_relaxed)
 	}
 	return true, nil
 }
 
+/**
+* @description: 从第V次处理中被松弛的结点出发，沿着父结点找出权重为负值的环路
+* @param graph:图
+* @param id: 第V次处理中被松弛的结点的`id`
+* @return: *NegativeCycleError
+ */
+func (a *BellmanFordShortestPath) negativeCycle(graph *Graph, id int) error {
+	vertex := graph.Vertexes[id]
+	for i := 0; i < graph.N() && vertex.GetParent() != nil; i++ {
+		vertex = vertex.GetParent()
+	}
+	if vertex.GetParent() == nil { //回溯到了源结点，最短路径权重只是超出了表示范围
+		return errors.New("BellmanFord error: shortest path weight is out of the range of int!")
+	}
+
+	cycle := []int{vertex.GetID()}
+	for v := vertex.GetParent(); v != nil && v != vertex; v = v.GetParent() {
+		cycle = append(cycle, v.GetID())
+	}
+	Revert(cycle)
+
+	weight := 0
+	for i := range cycle {
+		w, _ := graph.Weight(cycle[i], cycle[(i+1)%len(cycle)])
+		weight = AddDistance(weight, w)
+	}
+	return &NegativeCycleError{Cycle: cycle, Weight: weight}
+}
+
+/*!
+* @description: 点到点的最短路径
+* @param graph:图
+* @param source_id: 源结点`id`
+* @param target_id: 目标结点`id`
+* @return: 最短路径的权重；路径上的结点`id`，从source_id到target_id；目标不可达时返回*UnreachableError，存在从源结点可达的权重为负值的环路时返回*NegativeCycleError
+*
+* 边的权重可以为负值，所以无法在某个结点"确定"之后提前停止，仍然需要计算整棵最短路径树。
+ */
+func (a *BellmanFordShortestPath) ShortestPathTo(graph *Graph, source_id, target_id int) (int, []int, error) {
+	if err := checkPointToPoint(graph, source_id, target_id); err != nil {
+		return 0, nil, err
+	}
+	if _, err := a.ShortestPath(graph, source_id); err != nil {
+		return 0, nil, err
+	}
+
+	target := graph.Vertexes[target_id]
+	if Is_Unlimit(target.GetKey()) {
+		return 0, nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+	return target.GetKey(), vertexPath(graph, target_id), nil
+}
+
 /**
 * @description:单源最短路径的初始化操作
 * @param graph:图，必须非空
@@ -186,7 +258,9 @@ func (a *BellmanFordShortestPath) relax(from, to IVertex, weight int) error {
 	}
 
 	//u.key+weight为正无穷，则不可能松弛
-	if Is_Unlimit(from.GetKey() + weight) {
+	//使用饱和加法：正无穷加上负的权重仍为正无穷，结果不会溢出
+	sum := AddDistance(from.GetKey(), weight)
+	if Is_Unlimit(sum) {
 		return errors.New("weight is max")
 	}
 
@@ -194,8 +268,8 @@ func (a *BellmanFordShortestPath) relax(from, to IVertex, weight int) error {
 	//to.GetKey() > from.GetKey()+weight的情况出现有两种可能，
 	//一种是to没有被访问过，所以key=unlimit，
 	//另外一种是to被访问过了,并且计算好了总权重，但是当前是一条更短的路径，所以from + E(from,to)的值更小
-	if to.GetKey() > from.GetKey()+weight {
-		to.SetKey(from.GetKey() + weight)
+	if to.GetKey() > sum {
+		to.SetKey(sum)
 		to.SetParent(from)
 	}
 	return nil
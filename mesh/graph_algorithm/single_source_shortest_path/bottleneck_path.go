@@ -0,0 +1,211 @@
+/*
+ * @Description: 瓶颈路径：最宽路径(widest path)和最小最大路径(minimax path)，修改的Dijkstra算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 21:34:18
+ * @LastEditTime: 2026-10-19 21:34:18
+ * @LastEditors:
+ *
+ *
+ * ## 瓶颈路径
+ *
+ * 路径p的瓶颈值不是路径上所有边的权重之和，而是其中的最小值或者最大值：
+ *
+ * - 最宽路径：边的权重为容量，路径的容量为 min{w(e):e属于p}，求从s到v的容量最大的路径
+ * - 最小最大路径：路径的代价为 max{w(e):e属于p}，求从s到v的代价最小的路径
+ *
+ * ## 修改的Dijkstra算法
+ *
+ * 把Dijkstra算法中的"加法"换成min(或max)，把"最小"换成"最大"(或保持"最小")，松弛操作变为：
+ *
+ * - 最宽路径：若 min(b(u),w(u,v))>b(v)，则更新b(v)和v的前驱
+ * - 最小最大路径：若 max(b(u),w(u,v))<b(v)，则更新b(v)和v的前驱
+ *
+ * 由于min(或max)不会使路径"变得更好"，Dijkstra算法的贪心选择仍然成立，而且不要求边的权重非负。
+ * 源结点的瓶颈值为运算的单位元：最宽路径为Unlimit()，最小最大路径为-Unlimit()。
+ *
+ * 无向图用一对方向相反的有向边表示。时间复杂度与Dijkstra算法相同，使用二叉堆时为O((V+E)lgV)。
+ * 优先队列的关键字可能为负值，因此不能使用单调队列(DialQueue、RadixHeapQueue)。
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
+)
+
+type BottleneckMode int
+
+const (
+	BOTTLENECK_WIDEST  BottleneckMode = iota //最宽路径：最大化路径上的最小权重
+	BOTTLENECK_MINIMAX                       //最小最大路径：最小化路径上的最大权重
+)
+
+/**
+ * @description: 源结点的瓶颈值，即运算的单位元
+ */
+func (m BottleneckMode) Identity() int {
+	if m == BOTTLENECK_WIDEST {
+		return Unlimit()
+	}
+	return -Unlimit()
+}
+
+/**
+ * @description: 路径的瓶颈值为x，再经过一条权重为w的边之后的瓶颈值
+ */
+func (m BottleneckMode) Combine(x, w int) int {
+	if (m == BOTTLENECK_WIDEST) == (w < x) {
+		return w
+	}
+	return x
+}
+
+/**
+ * @description: 瓶颈值x是否优于y
+ */
+func (m BottleneckMode) Better(x, y int) bool {
+	if m == BOTTLENECK_WIDEST {
+		return x > y
+	}
+	return x < y
+}
+
+/**
+ * @description: 单源瓶颈路径的结果，即一棵瓶颈路径树
+ */
+type BottleneckTree struct {
+	Source int
+	values []int //values[v]为从源结点到v的瓶颈值
+	parent []int //parent[v]为v的前驱结点，-1表示不存在
+	found  []bool
+}
+
+// 结点v是否从源结点可达
+func (a *BottleneckTree) Reachable(v int) bool {
+	return v >= 0 && v < len(a.found) && a.found[v]
+}
+
+/**
+ * @description: 从源结点到v的瓶颈值
+ * @return: 瓶颈值；v不可达时返回*UnreachableError
+ */
+func (a *BottleneckTree) Value(v int) (int, error) {
+	if !a.Reachable(v) {
+		return 0, &UnreachableError{Source: a.Source, Target: v}
+	}
+	return a.values[v], nil
+}
+
+/**
+ * @description: 从源结点到v的瓶颈路径，v不可达时返回nil
+ */
+func (a *BottleneckTree) Path(v int) []int {
+	if !a.Reachable(v) {
+		return nil
+	}
+	path := []int{}
+	for x := v; x >= 0; x = a.parent[x] {
+		path = append(path, x)
+	}
+	Revert(path)
+	return path
+}
+
+type BottleneckPath struct {
+	Mode    BottleneckMode
+	creator PriorityQueueCreator
+}
+
+/**
+ * @description: 创建瓶颈路径算法
+ * @param mode: BOTTLENECK_WIDEST或BOTTLENECK_MINIMAX
+ * @param creators: 可选，优先队列的创建函数，不指定时使用NewBinaryHeapQueue
+ */
+func NewBottleneckPath(mode BottleneckMode, creators ...PriorityQueueCreator) *BottleneckPath {
+	a := &BottleneckPath{Mode: mode, creator: NewBinaryHeapQueue}
+	if len(creators) > 0 && creators[0] != nil {
+		a.creator = creators[0]
+	}
+	return a
+}
+
+/**
+ * @description: 计算从source_id出发到所有结点的瓶颈路径，不修改顶点的key和父结点
+ * @return: 瓶颈路径树
+ */
+func (a *BottleneckPath) ShortestPath(graph *Graph, source_id int) (*BottleneckTree, error) {
+	if graph == nil {
+		return nil, errors.New("BottleneckPath error: graph must not be nil!")
+	}
+	if source_id < 0 || source_id >= graph.N() || graph.Vertexes[source_id] == nil {
+		return nil, errors.New("BottleneckPath error: source_id muse belongs [0,N) and source vertex must not be nil!")
+	}
+	return a.search(graph, source_id, -1), nil
+}
+
+/**
+ * @description: 点到点的瓶颈路径，目标结点出队之后立即停止
+ * @return: 瓶颈值；路径上的结点`id`，从source_id到target_id；目标不可达时返回*UnreachableError
+ */
+func (a *BottleneckPath) ShortestPathTo(graph *Graph, source_id, target_id int) (int, []int, error) {
+	if err := checkPointToPoint(graph, source_id, target_id); err != nil {
+		return 0, nil, err
+	}
+	tree := a.search(graph, source_id, target_id)
+	value, err := tree.Value(target_id)
+	if err != nil {
+		return 0, nil, err
+	}
+	return value, tree.Path(target_id), nil
+}
+
+/**
+ * @description: 修改的Dijkstra算法
+ * @param target_id: 目标结点`id`，目标结点出队后立即停止；为-1时计算整棵瓶颈路径树
+ *
+ * 优先队列是最小优先队列，最宽路径以瓶颈值的相反数为关键字
+ */
+func (a *BottleneckPath) search(graph *Graph, source_id, target_id int) *BottleneckTree {
+	num := graph.N()
+	tree := &BottleneckTree{Source: source_id, values: make([]int, num), parent: make([]int, num), found: make([]bool, num)}
+	for i := 0; i < num; i++ {
+		tree.parent[i] = -1
+	}
+	key := func(value int) int {
+		if a.Mode == BOTTLENECK_WIDEST {
+			return -value
+		}
+		return value
+	}
+
+	settled := make([]bool, num)
+	q := a.creator(num)
+	tree.values[source_id] = a.Mode.Identity()
+	tree.found[source_id] = true
+	q.Push(source_id, key(tree.values[source_id]))
+	for q.Len() > 0 {
+		u, _ := q.Pop()
+		settled[u] = true
+		if u == target_id {
+			break
+		}
+		edges, _ := graph.VertexEdgeTuples(u)
+		for _, edge := range edges {
+			v := edge.Second
+			if settled[v] {
+				continue
+			}
+			value := a.Mode.Combine(tree.values[u], edge.Third)
+			if !tree.found[v] || a.Mode.Better(value, tree.values[v]) {
+				tree.values[v] = value
+				tree.parent[v] = u
+				tree.found[v] = true
+				q.Push(v, key(value))
+			}
+		}
+	}
+	return tree
+}
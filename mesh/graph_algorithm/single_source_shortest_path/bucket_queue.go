@@ -0,0 +1,226 @@
+/*
+ * @Description: 单调优先队列：Dial算法的桶队列以及基数堆，用于边的权重为较小的非负整数时的Dijkstra算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 21:10:44
+ * @LastEditTime: 2026-10-19 21:10:44
+ * @LastEditors:
+ *
+ *
+ * Dijkstra算法中，从队列中弹出的关键字是单调不减的，而且队列中所有的关键字都属于[d,d+C]，其中d为上一次弹出的关键字，C为边的最大权重。
+ * 利用这个性质可以用比二叉堆更简单的结构实现优先队列。这两种队列都实现了IndexedPriorityQueue，通过NewDijkstra的参数选择，
+ * 得到的结果(结点的key和父结点)与其他优先队列相同。
+ *
+ * ## Dial算法(桶队列)
+ *
+ * 使用C+1个桶组成的循环数组，关键字为k的元素放在第k%(C+1)个桶中，每个桶是一个双向链表，因此插入和减小关键字都是O(1)的。
+ * 弹出时从上一次弹出的关键字开始依次向后查找第一个非空的桶，由于队列中的关键字都属于[d,d+C]，同一个桶中的元素关键字相同。
+ * Dijkstra算法的时间复杂度为O(E+VC)，适合C很小的情况。
+ *
+ * 如果给出的C小于边的最大权重，Push遇到超出[d,d+C]的关键字时会扩大循环数组并重新分桶，结果仍然正确，只是多了重新分桶的代价。
+ *
+ * ## 基数堆(radix heap)
+ *
+ * 记上一次弹出的关键字为last，关键字为k的元素放在第bits.Len(k^last)个桶中，即按照k与last的二进制表示中最高的不同位分桶。
+ * 第0个桶中元素的关键字都等于last。弹出时如果第0个桶为空，则找到第一个非空的桶i，令last为其中的最小关键字，
+ * 然后把桶i中的元素重新分配到编号更小的桶中。每个元素的桶编号只会减小，因此Dijkstra算法的时间复杂度为O(E+VlgC)。
+ *
+ * 两种队列都是单调的：要求Push的关键字不小于上一次Pop返回的关键字。
+ */
+package SingleSourceShortestPath
+
+import (
+	"math/bits"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
+)
+
+type DialQueue struct {
+	heads []int //heads[b]为第b个桶的链表头，-1表示空桶
+	next  []int //next[id]、prev[id]为元素id在桶中的后继和前驱，-1表示不存在
+	prev  []int
+	keys  []int //keys[id]为元素id的关键字
+	in    []bool
+	last  int //上一次弹出的关键字
+	size  int
+}
+
+/**
+ * @description: Dial算法的桶队列的创建函数
+ * @param max_weight: 边的最大权重C，应当不小于图中所有边的权重(偏小时队列会扩大)；为负值时panic
+ */
+func NewDialQueue(max_weight int) PriorityQueueCreator {
+	if max_weight < 0 {
+		panic("NewDialQueue error: max_weight must not be negative!")
+	}
+	return func(n int) IndexedPriorityQueue {
+		heads := make([]int, max_weight+1)
+		for i := range heads {
+			heads[i] = -1
+		}
+		return &DialQueue{heads: heads, next: make([]int, n), prev: make([]int, n), keys: make([]int, n), in: make([]bool, n)}
+	}
+}
+
+func (a *DialQueue) Len() int {
+	return a.size
+}
+
+func (a *DialQueue) Push(id, key int) {
+	if a.in[id] {
+		if key >= a.keys[id] {
+			return
+		}
+		a.unlink(id)
+	}
+	if key < a.last {
+		panic("DialQueue error: key must not be less than the last popped key!")
+	}
+	if key-a.last >= len(a.heads) {
+		a.grow(key - a.last + 1)
+	}
+	a.keys[id] = key
+	a.in[id] = true
+	a.size++
+	a.link(id)
+}
+
+func (a *DialQueue) Pop() (int, int) {
+	if a.size == 0 {
+		return -1, 0
+	}
+	for key := a.last; ; key++ {
+		if id := a.heads[key%len(a.heads)]; id >= 0 {
+			a.last = key
+			a.unlink(id)
+			return id, a.keys[id]
+		}
+	}
+}
+
+// 把元素id放入关键字对应的桶中
+func (a *DialQueue) link(id int) {
+	b := a.keys[id] % len(a.heads)
+	a.prev[id] = -1
+	a.next[id] = a.heads[b]
+	if a.heads[b] >= 0 {
+		a.prev[a.heads[b]] = id
+	}
+	a.heads[b] = id
+}
+
+// 关键字超出了[last,last+C]：把桶的数目扩大到至少size个(至少翻倍)，并把队列中的元素重新分桶
+func (a *DialQueue) grow(size int) {
+	if size < 2*len(a.heads) {
+		size = 2 * len(a.heads)
+	}
+	ids := []int{}
+	for _, head := range a.heads {
+		for id := head; id >= 0; id = a.next[id] {
+			ids = append(ids, id)
+		}
+	}
+	a.heads = make([]int, size)
+	for i := range a.heads {
+		a.heads[i] = -1
+	}
+	for _, id := range ids {
+		a.link(id)
+	}
+}
+
+// 把元素id从它所在的桶中删除
+func (a *DialQueue) unlink(id int) {
+	if a.prev[id] >= 0 {
+		a.next[a.prev[id]] = a.next[id]
+	} else {
+		a.heads[a.keys[id]%len(a.heads)] = a.next[id]
+	}
+	if a.next[id] >= 0 {
+		a.prev[a.next[id]] = a.prev[id]
+	}
+	a.in[id] = false
+	a.size--
+}
+
+type RadixHeapQueue struct {
+	buckets [bits.UintSize + 1][]int //buckets[b]存放元素id
+	bucket  []int                    //bucket[id]为元素id所在的桶，-1表示不在队列中
+	index   []int                    //index[id]为元素id在桶中的位置
+	keys    []int
+	last    int
+	size    int
+}
+
+/**
+ * @description: 基数堆的创建函数，要求关键字非负
+ */
+func NewRadixHeapQueue(n int) IndexedPriorityQueue {
+	bucket := make([]int, n)
+	for i := range bucket {
+		bucket[i] = -1
+	}
+	return &RadixHeapQueue{bucket: bucket, index: make([]int, n), keys: make([]int, n)}
+}
+
+func (a *RadixHeapQueue) Len() int {
+	return a.size
+}
+
+func (a *RadixHeapQueue) Push(id, key int) {
+	if a.bucket[id] >= 0 {
+		if key >= a.keys[id] {
+			return
+		}
+		a.remove(id)
+		a.size--
+	}
+	a.keys[id] = key
+	a.insert(id)
+	a.size++
+}
+
+func (a *RadixHeapQueue) Pop() (int, int) {
+	if a.size == 0 {
+		return -1, 0
+	}
+	if len(a.buckets[0]) == 0 {
+		b := 1
+		for len(a.buckets[b]) == 0 {
+			b++
+		}
+		ids := a.buckets[b]
+		a.last = a.keys[ids[0]]
+		for _, id := range ids {
+			if a.keys[id] < a.last {
+				a.last = a.keys[id]
+			}
+		}
+		a.buckets[b] = ids[:0]
+		for _, id := range ids {
+			a.insert(id)
+		}
+	}
+	id := a.buckets[0][len(a.buckets[0])-1]
+	a.remove(id)
+	a.size--
+	return id, a.keys[id]
+}
+
+// 按照关键字与last的最高不同位把元素id放入桶中
+func (a *RadixHeapQueue) insert(id int) {
+	b := bits.Len(uint(a.keys[id] ^ a.last))
+	a.bucket[id] = b
+	a.index[id] = len(a.buckets[b])
+	a.buckets[b] = append(a.buckets[b], id)
+}
+
+// 把元素id从它所在的桶中删除：用桶中最后一个元素填补它的位置
+func (a *RadixHeapQueue) remove(id int) {
+	b, i := a.bucket[id], a.index[id]
+	ids := a.buckets[b]
+	tail := ids[len(ids)-1]
+	ids[i] = tail
+	a.index[tail] = i
+	a.buckets[b] = ids[:len(ids)-1]
+	a.bucket[id] = -1
+}
@@ -0,0 +1,416 @@
+/*
+ * @Description: 收缩层次(Contraction Hierarchies)，用于静态图上大量的点到点最短路径查询
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 20:11:52
+ * @LastEditTime: 2026-10-19 20:11:52
+ * @LastEditors:
+ *
+ *
+ * ## 预处理
+ *
+ * 按照某种顺序依次"收缩"图中的结点：收缩结点v时，把v从图中删除，对于每一对边 u-->v-->w，如果删除v之后u到w的最短路径权重变大，
+ * 就添加一条捷径(shortcut) u-->w，权重为w(u,v)+w(v,w)，并且记住它的中间结点v。
+ *
+ * - 见证搜索(witness search)：以u为源点，在删除了v的剩余图中执行一次有限制的Dijkstra算法。如果找到一条权重不超过w(u,v)+w(v,w)的
+ * u到w的路径(称为见证路径)，则不需要捷径。为了控制预处理时间，搜索最多确定WitnessLimit个结点，找不到见证路径时添加捷径，这不影响正确性
+ * - 结点顺序：结点的优先级为"边差"，即收缩该结点需要添加的捷径数减去与之相连的边数，再加上已经被收缩的邻居个数(使收缩均匀地分布在图中)。
+ * 每次取出优先级最小的结点，重新计算它的优先级(惰性更新)，如果仍然不大于队列中的最小值才收缩它
+ *
+ * 结点的收缩顺序称为它的等级rank。原图的边和所有捷径构成一个新图，其中从低等级结点指向高等级结点的边称为向上的边。
+ *
+ * ## 查询
+ *
+ * 对于任意结点s、t，s到t的最短路径一定可以表示成：先沿着向上的边从s走到某个等级最高的结点x，再沿着向下的边从x走到t。
+ * 因此从s出发只沿着向上的边做正向Dijkstra搜索，从t出发只沿着(反向的)向下的边做反向Dijkstra搜索，两个搜索在x处相遇。
+ * 当两个方向队列中的最小值都不小于当前找到的最短距离时停止。
+ *
+ * 最后把路径上的每条捷径递归地替换为 u-->中间结点-->w，得到原图中的路径。
+ *
+ * 性能：预处理时间与图的结构有关，道路网络上通常只会添加O(V)条捷径；每次查询只会访问很少的结点
+ */
+package SingleSourceShortestPath
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+	"sync"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+// 见证搜索中最多确定的结点数
+const CH_WITNESS_LIMIT = 500
+
+type ContractionHierarchy struct {
+	WitnessLimit int //见证搜索中最多确定的结点数
+
+	num       int
+	valid     []bool       //valid[id]表示原图中结点id存在
+	rank      []int        //结点的收缩顺序
+	up        [][]chEdge   //up[u]为从u出发、指向更高等级结点的边
+	down      [][]chEdge   //down[w]为指向w、从更高等级结点出发的边，边的to为起点
+	middle    map[Pair]int //捷径(from,to)的中间结点
+	shortcuts int          //捷径的数目
+	pool      sync.Pool    //查询使用的*chQueryState，避免每次查询都分配O(V)的数组；结点数与num不同的状态会被丢弃
+}
+
+type chEdge struct {
+	to, weight int
+}
+
+// 预处理过程中剩余图的一条边，middle为-1表示原图的边
+type chArc struct {
+	weight, middle int
+}
+
+func NewContractionHierarchy() *ContractionHierarchy {
+	return &ContractionHierarchy{WitnessLimit: CH_WITNESS_LIMIT}
+}
+
+/*!
+ * @description: 预处理，构造收缩层次
+ * @param graph: 有向图，边的权重必须非负
+ * @return: error
+ *
+ * 预处理之后不再依赖graph，图发生变化时需要重新预处理
+ */
+func (a *ContractionHierarchy) Preprocess(graph *Graph) error {
+	if graph == nil {
+		return errors.New("ContractionHierarchy error: graph must not be nil!")
+	}
+	num := graph.N()
+	out := make([]map[int]*chArc, num)
+	in := make([]map[int]*chArc, num)
+	for i := 0; i < num; i++ {
+		out[i] = map[int]*chArc{}
+		in[i] = map[int]*chArc{}
+	}
+	addArc := func(from, to, weight, middle int) {
+		if arc, ok := out[from][to]; ok && arc.weight <= weight {
+			return
+		}
+		arc := &chArc{weight: weight, middle: middle}
+		out[from][to] = arc
+		in[to][from] = arc
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if edge.Third < 0 {
+			return errors.New("ContractionHierarchy error: edge weight must not be negative!")
+		}
+		if edge.First != edge.Second {
+			addArc(edge.First, edge.Second, edge.Third, -1)
+		}
+	}
+
+	a.num = num
+	a.valid = make([]bool, num)
+	a.rank = make([]int, num)
+	a.up = make([][]chEdge, num)
+	a.down = make([][]chEdge, num)
+	a.middle = map[Pair]int{}
+	a.shortcuts = 0
+	limit := a.WitnessLimit
+	if limit <= 0 {
+		limit = CH_WITNESS_LIMIT
+	}
+	witness := newCHWitness(num)
+	deleted_neighbors := make([]int, num)
+
+	//*********** 计算收缩结点v需要添加的捷径 ****************
+	contract := func(v int) []*Tuple {
+		shortcuts := []*Tuple{}
+		targets := sortedArcKeys(out[v])
+		for _, u := range sortedArcKeys(in[v]) {
+			max_dist := 0
+			for _, w := range targets {
+				if d := AddDistance(in[v][u].weight, out[v][w].weight); w != u && d > max_dist {
+					max_dist = d
+				}
+			}
+			witness.search(out, u, v, max_dist, limit)
+			for _, w := range targets {
+				if w == u {
+					continue
+				}
+				weight := AddDistance(in[v][u].weight, out[v][w].weight)
+				if !Is_Unlimit(weight) && witness.distance(w) > weight {
+					shortcuts = append(shortcuts, NewTuple(u, w, weight))
+				}
+			}
+		}
+		return shortcuts
+	}
+	priority := func(v int) int {
+		return len(contract(v)) - len(in[v]) - len(out[v]) + deleted_neighbors[v]
+	}
+
+	//*********** 按照优先级依次收缩结点 ****************
+	queue := &chHeap{}
+	for v := 0; v < num; v++ {
+		if graph.Vertexes[v] != nil {
+			a.valid[v] = true
+			heap.Push(queue, chItem{id: v, key: priority(v)})
+		}
+	}
+	order := 0
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(chItem)
+		v := item.id
+		if p := priority(v); queue.Len() > 0 && p > (*queue)[0].key { //惰性更新
+			heap.Push(queue, chItem{id: v, key: p})
+			continue
+		}
+
+		shortcuts := contract(v)
+		a.rank[v] = order
+		order++
+		for _, w := range sortedArcKeys(out[v]) {
+			arc := out[v][w]
+			a.up[v] = append(a.up[v], chEdge{to: w, weight: arc.weight})
+			if arc.middle >= 0 {
+				a.middle[Pair{First: v, Second: w}] = arc.middle
+			}
+			delete(in[w], v)
+			deleted_neighbors[w]++
+		}
+		for _, u := range sortedArcKeys(in[v]) {
+			arc := in[v][u]
+			a.down[v] = append(a.down[v], chEdge{to: u, weight: arc.weight})
+			if arc.middle >= 0 {
+				a.middle[Pair{First: u, Second: v}] = arc.middle
+			}
+			delete(out[u], v)
+			deleted_neighbors[u]++
+		}
+		out[v], in[v] = nil, nil
+		for _, shortcut := range shortcuts {
+			addArc(shortcut.First, shortcut.Second, shortcut.Third, v)
+			a.shortcuts++
+		}
+	}
+	return nil
+}
+
+// 预处理添加的捷径数目
+func (a *ContractionHierarchy) ShortcutCount() int {
+	return a.shortcuts
+}
+
+/*!
+ * @description: 点到点最短路径查询，可以在多个goroutine中同时调用，但不能与Preprocess同时调用
+ * @param source_id: 源结点`id`
+ * @param target_id: 目标结点`id`
+ * @return: 最短路径的权重；原图中的路径，从source_id到target_id；目标不可达时返回*UnreachableError
+ */
+func (a *ContractionHierarchy) Query(source_id, target_id int) (int, []int, error) {
+	if a.valid == nil {
+		return 0, nil, errors.New("ContractionHierarchy error: must preprocess before query!")
+	}
+	if source_id < 0 || source_id >= a.num || !a.valid[source_id] || target_id < 0 || target_id >= a.num || !a.valid[target_id] {
+		return 0, nil, errors.New("ContractionHierarchy error: source_id and target_id must belongs [0,N) and vertexes must not be nil!")
+	}
+
+	//*********** 双向搜索：0为正向，1为反向 ****************
+	state, _ := a.pool.Get().(*chQueryState)
+	if state == nil || len(state.dist[0]) != a.num { //池中可能有重新预处理之前、结点数不同的状态
+		state = newCHQueryState(a.num)
+	}
+	defer a.pool.Put(state)
+	state.reset()
+	state.visit(0, source_id, 0, -1)
+	state.visit(1, target_id, 0, -1)
+	edges := [2][][]chEdge{a.up, a.down}
+	best, meet := Unlimit(), -1
+	for {
+		side := -1
+		for d := 0; d < 2; d++ {
+			if q := state.queues[d]; q.Len() > 0 && q[0].key < best && (side < 0 || q[0].key < state.queues[side][0].key) {
+				side = d
+			}
+		}
+		if side < 0 {
+			break
+		}
+		item := heap.Pop(&state.queues[side]).(chItem)
+		u := item.id
+		if item.key > state.dist[side][u] {
+			continue
+		}
+		if d := AddDistance(item.key, state.dist[1-side][u]); !Is_Unlimit(d) && d < best {
+			best, meet = d, u
+		}
+		for _, edge := range edges[side][u] {
+			if d := AddDistance(item.key, edge.weight); !Is_Unlimit(d) && d < state.dist[side][edge.to] {
+				state.visit(side, edge.to, d, u)
+			}
+		}
+	}
+	if meet < 0 {
+		return 0, nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+
+	//*********** 展开路径 ****************
+	vertexes := []int{}
+	for v := meet; v >= 0; v = state.parent[0][v] {
+		vertexes = append(vertexes, v)
+	}
+	Revert(vertexes)
+	for v := state.parent[1][meet]; v >= 0; v = state.parent[1][v] {
+		vertexes = append(vertexes, v)
+	}
+	path := []int{source_id}
+	for i := 1; i < len(vertexes); i++ {
+		path = a.unpack(vertexes[i-1], vertexes[i], path)
+	}
+	return best, path, nil
+}
+
+/**
+ * @description: 把边from-->to展开为原图中的路径，追加到path之后(不包括from)
+ */
+func (a *ContractionHierarchy) unpack(from, to int, path []int) []int {
+	stack := []Pair{{First: from, Second: to}}
+	for len(stack) > 0 {
+		arc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if m, ok := a.middle[arc]; ok {
+			stack = append(stack, Pair{First: m, Second: arc.Second}, Pair{First: arc.First, Second: m})
+		} else {
+			path = append(path, arc.Second)
+		}
+	}
+	return path
+}
+
+/**
+ * @description: 双向搜索的状态，每次查询之后只重置访问过的结点
+ */
+type chQueryState struct {
+	dist    [2][]int
+	parent  [2][]int
+	touched [2][]int
+	queues  [2]chHeap
+}
+
+func newCHQueryState(num int) *chQueryState {
+	state := &chQueryState{}
+	for d := 0; d < 2; d++ {
+		state.dist[d] = make([]int, num)
+		state.parent[d] = make([]int, num)
+		for i := 0; i < num; i++ {
+			state.dist[d][i] = Unlimit()
+		}
+	}
+	return state
+}
+
+func (a *chQueryState) reset() {
+	for d := 0; d < 2; d++ {
+		for _, id := range a.touched[d] {
+			a.dist[d][id] = Unlimit()
+		}
+		a.touched[d] = a.touched[d][:0]
+		a.queues[d] = a.queues[d][:0]
+	}
+}
+
+func (a *chQueryState) visit(side, id, dist, parent int) {
+	if Is_Unlimit(a.dist[side][id]) {
+		a.touched[side] = append(a.touched[side], id)
+	}
+	a.dist[side][id] = dist
+	a.parent[side][id] = parent
+	heap.Push(&a.queues[side], chItem{id: id, key: dist})
+}
+
+/**
+ * @description: 见证搜索，重复使用dist数组，每次搜索之后只重置访问过的结点
+ */
+type chWitness struct {
+	dist    []int
+	touched []int
+	queue   chHeap
+}
+
+func newCHWitness(num int) *chWitness {
+	dist := make([]int, num)
+	for i := range dist {
+		dist[i] = Unlimit()
+	}
+	return &chWitness{dist: dist}
+}
+
+/**
+ * @description: 在剩余图中以source为源点执行Dijkstra算法，忽略结点excluded；距离超过max_dist或者确定了limit个结点后停止
+ */
+func (a *chWitness) search(out []map[int]*chArc, source, excluded, max_dist, limit int) {
+	for _, id := range a.touched {
+		a.dist[id] = Unlimit()
+	}
+	a.touched = a.touched[:0]
+	a.queue = a.queue[:0]
+
+	a.dist[source] = 0
+	a.touched = append(a.touched, source)
+	heap.Push(&a.queue, chItem{id: source, key: 0})
+	for count := 0; a.queue.Len() > 0 && count < limit; {
+		item := heap.Pop(&a.queue).(chItem)
+		if item.key > a.dist[item.id] {
+			continue
+		}
+		if item.key > max_dist {
+			break
+		}
+		count++
+		for to, arc := range out[item.id] {
+			if to == excluded {
+				continue
+			}
+			if d := AddDistance(item.key, arc.weight); !Is_Unlimit(d) && d < a.dist[to] {
+				if Is_Unlimit(a.dist[to]) {
+					a.touched = append(a.touched, to)
+				}
+				a.dist[to] = d
+				heap.Push(&a.queue, chItem{id: to, key: d})
+			}
+		}
+	}
+}
+
+// 最近一次搜索得到的距离上界
+func (a *chWitness) distance(id int) int {
+	return a.dist[id]
+}
+
+func sortedArcKeys(arcs map[int]*chArc) []int {
+	keys := make([]int, 0, len(arcs))
+	for k := range arcs {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+type chItem struct {
+	id, key int
+}
+
+type chHeap []chItem
+
+func (h chHeap) Len() int { return len(h) }
+func (h chHeap) Less(i, j int) bool {
+	if h[i].key != h[j].key {
+		return h[i].key < h[j].key
+	}
+	return h[i].id < h[j].id
+}
+func (h chHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *chHeap) Push(x interface{}) { *h = append(*h, x.(chItem)) }
+func (h *chHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,181 @@
+/*
+ * @Description: 第24章24.2节 关键路径(PERT图)，有向无环图中的最长路径
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 20:52:36
+ * @LastEditTime: 2026-10-19 20:52:36
+ * @LastEditors:
+ *
+ *
+ * ## 关键路径
+ *
+ * 在项目调度中，每个任务(结点)v需要持续时间d(v)，边(u,v)表示任务v必须在任务u完成之后才能开始，边的权重为u完成与v开始之间的最小间隔(通常为0)。
+ * 这样的有向无环图称为PERT图。关键路径是图中的一条最长路径，它的长度就是完成所有任务所需的最短时间(工期)，关键路径上的任何一个任务延迟都会导致整个项目延迟。
+ *
+ * 把权重取负值之后，最长路径问题就变成了最短路径问题；在有向无环图中不存在环路，因此可以按照拓扑排序的顺序用松弛操作在O(V+E)时间内求解：
+ *
+ * - 最早开始时间 ES(v)=max{ES(u)+d(u)+w(u,v)}，没有前驱的任务 ES(v)=0；最早完成时间 EF(v)=ES(v)+d(v)
+ * - 工期 T=max{EF(v)}
+ * - 最晚完成时间 LF(v)=min{LS(x)-w(v,x)}，没有后继的任务 LF(v)=T；最晚开始时间 LS(v)=LF(v)-d(v)
+ * - 松弛时间 slack(v)=LS(v)-ES(v)，松弛时间为0的任务为关键任务
+ *
+ * 正向的计算按照拓扑排序的顺序进行，反向的计算按照拓扑排序的逆序进行。
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/basic_graph"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 一个任务的调度信息
+ */
+type TaskSchedule struct {
+	ID             int  //任务(结点)的`id`
+	Duration       int  //持续时间
+	EarliestStart  int  //最早开始时间
+	EarliestFinish int  //最早完成时间
+	LatestStart    int  //最晚开始时间
+	LatestFinish   int  //最晚完成时间
+	Slack          int  //松弛时间，LatestStart-EarliestStart
+	Critical       bool //是否为关键任务，即松弛时间为0
+}
+
+/**
+ * @description: 关键路径的计算结果
+ */
+type CriticalPathReport struct {
+	Makespan int             //工期，完成所有任务所需的最短时间
+	Path     []int           //一条关键路径上的任务`id`，按照执行的顺序
+	Tasks    []*TaskSchedule //Tasks[id]为任务id的调度信息，图中不存在的结点为nil
+}
+
+/**
+ * @description: 以表格的形式输出每个任务的调度信息
+ */
+func (a *CriticalPathReport) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "makespan: %d, critical path: %v\n", a.Makespan, a.Path)
+	fmt.Fprintf(&builder, "%6s %8s %6s %6s %6s %6s %6s %8s\n", "task", "duration", "ES", "EF", "LS", "LF", "slack", "critical")
+	for _, task := range a.Tasks {
+		if task != nil {
+			fmt.Fprintf(&builder, "%6d %8d %6d %6d %6d %6d %6d %8v\n", task.ID, task.Duration,
+				task.EarliestStart, task.EarliestFinish, task.LatestStart, task.LatestFinish, task.Slack, task.Critical)
+		}
+	}
+	return builder.String()
+}
+
+type CriticalPath struct {
+}
+
+func NewCriticalPath() *CriticalPath {
+	return &CriticalPath{}
+}
+
+/*!
+ * @description: 计算PERT图的关键路径以及每个任务的调度信息
+ * @param graph: 有向无环图，结点必须是*DFSVertex(拓扑排序使用深度优先搜索)，结点的key为任务的持续时间，边的权重为两个任务之间的最小间隔
+ * @return: 调度结果；graph为空、结点类型不正确、持续时间或者间隔为负值以及图中存在环路时返回error
+ *
+ * ### 算法步骤
+ *
+ * - 对有向无环图进行拓扑排序，并检查每条边(u,v)在拓扑排序中都满足u位于v的前面，否则图中存在环路
+ * - 按照拓扑排序的顺序，对每个结点u出发的边(u,v)执行最长路径的松弛操作：若ES(u)+d(u)+w(u,v)>ES(v)，则更新ES(v)和v的前驱
+ * - 按照拓扑排序的逆序，对每个结点v出发的边(v,x)计算LF(v)
+ * - 从最早完成时间最大的任务出发，沿着前驱回溯得到关键路径
+ *
+ * ### 算法性能
+ *
+ * 时间复杂度为O(V+E)
+ */
+func (a *CriticalPath) Schedule(graph *Graph) (*CriticalPathReport, error) {
+	if graph == nil {
+		return nil, errors.New("CriticalPath error: graph must not be nil!")
+	}
+	num := graph.N()
+	count := 0
+	for _, vertex := range graph.Vertexes {
+		if vertex == nil {
+			continue
+		}
+		if _, ok := vertex.(*DFSVertex); !ok {
+			return nil, errors.New("CriticalPath error: vertex must be *DFSVertex!")
+		}
+		if vertex.GetKey() < 0 {
+			return nil, errors.New("CriticalPath error: duration must not be negative!")
+		}
+		count++
+	}
+
+	//拓扑排序的结果长度为N，前面N-count个元素是为空结点填充的0
+	sorted, _ := NewTopologySort().Sort(graph)
+	order := sorted[len(sorted)-count:]
+	position := make([]int, num)
+	for i, id := range order {
+		position[id] = i
+	}
+	adj := make([][]*Tuple, num)
+	for _, id := range order {
+		adj[id], _ = graph.VertexEdgeTuples(id)
+		for _, edge := range adj[id] {
+			if edge.Third < 0 {
+				return nil, errors.New("CriticalPath error: edge weight must not be negative!")
+			}
+			if position[edge.Second] <= position[id] {
+				return nil, errors.New("CriticalPath error: graph must be a DAG!")
+			}
+		}
+	}
+
+	report := &CriticalPathReport{Tasks: make([]*TaskSchedule, num)}
+	parent := make([]int, num)
+	for _, id := range order {
+		report.Tasks[id] = &TaskSchedule{ID: id, Duration: graph.Vertexes[id].GetKey()}
+		parent[id] = -1
+	}
+
+	//************* 正向：最早开始时间  ***************
+	last := -1
+	for _, u := range order {
+		task := report.Tasks[u]
+		task.EarliestFinish = task.EarliestStart + task.Duration
+		for _, edge := range adj[u] {
+			if to := report.Tasks[edge.Second]; task.EarliestFinish+edge.Third > to.EarliestStart {
+				to.EarliestStart = task.EarliestFinish + edge.Third
+				parent[edge.Second] = u
+			}
+		}
+		if last < 0 || task.EarliestFinish > report.Makespan {
+			report.Makespan = task.EarliestFinish
+			last = u
+		}
+	}
+
+	//************* 反向：最晚完成时间  ***************
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		task := report.Tasks[v]
+		task.LatestFinish = report.Makespan
+		for _, edge := range adj[v] {
+			if ls := report.Tasks[edge.Second].LatestStart - edge.Third; ls < task.LatestFinish {
+				task.LatestFinish = ls
+			}
+		}
+		task.LatestStart = task.LatestFinish - task.Duration
+		task.Slack = task.LatestStart - task.EarliestStart
+		task.Critical = task.Slack == 0
+	}
+
+	for v := last; v >= 0; v = parent[v] {
+		report.Path = append(report.Path, v)
+	}
+	Revert(report.Path)
+	return report, nil
+}
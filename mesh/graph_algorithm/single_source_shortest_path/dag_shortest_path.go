@@ -145,12 +145,14 @@ func (a *DagShortestPath) relax(from, to IVertex, weight int) error {
 		return errors.New("relax error: from_vertex must not be to_vertex!")
 	}
 
-	if Is_Unlimit(from.GetKey() + weight) { //u.key+weight为正无穷，则不可能松弛
+	//u.key+weight为正无穷，则不可能松弛。使用饱和加法，正无穷加上负的权重仍为正无穷
+	sum := AddDistance(from.GetKey(), weight)
+	if Is_Unlimit(sum) {
 		return errors.New("distance is max")
 	}
 
-	if to.GetKey() > from.GetKey()+weight {
-		to.SetKey(from.GetKey() + weight)
+	if to.GetKey() > sum {
+		to.SetKey(sum)
 		to.SetParent(from)
 	}
 	return nil
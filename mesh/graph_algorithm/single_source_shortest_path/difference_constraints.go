@@ -0,0 +1,191 @@
+/*
+ * @Description: 第24章24.4节 差分约束和最短路径
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 20:31:07
+ * @LastEditTime: 2026-10-19 20:31:07
+ * @LastEditors:
+ *
+ *
+ * ## 差分约束系统
+ *
+ * 在一个差分约束系统中，线性规划矩阵A的每一行包括一个1和一个-1，其他所有项都是0。也就是说，每个约束条件都是形如 x_j - x_i <= b_k 的不等式。
+ *
+ * 给定差分约束系统Ax<=b，其约束图G=(V,E)的定义为：
+ *
+ * - V={v0,v1,...,vn}，每个变量x_i对应一个结点v_i，另外增加一个额外的结点v0(虚拟源结点)
+ * - E={(v_i,v_j): x_j-x_i<=b_k 是一个约束条件}并上{(v0,v1),(v0,v2),...,(v0,vn)}
+ * - 边(v_i,v_j)的权重为b_k，从v0出发的边的权重为0
+ *
+ * 定理24.9：如果约束图G不包含权重为负值的环路，则 x=(delt(v0,v1),delt(v0,v2),...,delt(v0,vn)) 是该系统的一个可行解；
+ * 如果约束图G包含权重为负值的环路，则该系统没有可行解。此时环路上的约束条件相加得到 0<=环路权重<0，这组约束条件就是系统不可行的原因。
+ *
+ * 因此可以用Bellman-Ford算法来求解差分约束系统，时间复杂度为O(nm)，n为变量的数目，m为约束条件的数目。
+ * 同一对变量之间的多个约束条件中，只有b最小的那个起作用。
+ *
+ * 若x是一个可行解，则对任意常数d，x+d也是一个可行解。Bellman-Ford算法给出的解满足 max{x_i}=0 (每个变量都小于等于0)。
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 一个差分约束条件 x_J - x_I <= B
+ */
+type DifferenceConstraint struct {
+	J, I int //变量的下标
+	B    int
+}
+
+type DifferenceConstraints struct {
+	names       []string       //names[i]为变量i的名字，按下标添加的变量没有名字
+	index       map[string]int //变量名字到下标的映射
+	constraints map[Pair]int   //(i,j)-->b，x_j - x_i <= b，只保留最小的b
+}
+
+func NewDifferenceConstraints() *DifferenceConstraints {
+	return &DifferenceConstraints{index: map[string]int{}, constraints: map[Pair]int{}}
+}
+
+/**
+ * @description: 变量的数目，下标为[0,N())
+ */
+func (a *DifferenceConstraints) N() int {
+	return len(a.names)
+}
+
+/**
+ * @description: 返回名字为name的变量的下标，如果该变量不存在则添加它
+ */
+func (a *DifferenceConstraints) Variable(name string) int {
+	if id, ok := a.index[name]; ok {
+		return id
+	}
+	a.names = append(a.names, name)
+	a.index[name] = len(a.names) - 1
+	return len(a.names) - 1
+}
+
+/**
+ * @description: 变量i的名字，按下标添加的变量的名字为空
+ */
+func (a *DifferenceConstraints) Name(i int) string {
+	if i < 0 || i >= len(a.names) {
+		return ""
+	}
+	return a.names[i]
+}
+
+/**
+ * @description: 添加约束条件 x_j - x_i <= b，下标超出N()时自动添加变量
+ * @return: 下标为负时返回error
+ */
+func (a *DifferenceConstraints) AddConstraint(j, i, b int) error {
+	if i < 0 || j < 0 {
+		return errors.New("AddConstraint error: variable index must not be negative!")
+	}
+	for len(a.names) <= i || len(a.names) <= j {
+		a.names = append(a.names, "")
+	}
+	key := Pair{First: i, Second: j}
+	if old, ok := a.constraints[key]; !ok || b < old {
+		a.constraints[key] = b
+	}
+	return nil
+}
+
+/**
+ * @description: 添加约束条件 xj - xi <= b，变量由名字指定
+ */
+func (a *DifferenceConstraints) AddNamedConstraint(xj, xi string, b int) error {
+	return a.AddConstraint(a.Variable(xj), a.Variable(xi), b)
+}
+
+/*!
+ * @description: 求解差分约束系统
+ * @return: 可行解x，x[i]为变量i的值；系统不可行时返回*NegativeCycleError，
+ *		其Cycle为约束图中的一个权重为负值的环路(变量下标)，可以由CycleConstraints得到对应的约束条件
+ *
+ * ### 算法步骤
+ *
+ * - 构造约束图：结点0..n-1对应变量，结点n为虚拟源结点
+ * - 对每个约束条件 x_j - x_i <= b 添加边(i,j)，权重为b；对每个变量i添加边(n,i)，权重为0
+ * - 以结点n为源结点执行Bellman-Ford算法。虚拟源结点没有入边，不会出现在任何环路上
+ */
+func (a *DifferenceConstraints) Solve() ([]int, error) {
+	num := a.N()
+	graph := NewGraph(0, num+1, func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i <= num; i++ {
+		graph.AddVertex(0)
+	}
+	for key, b := range a.constraints {
+		graph.AddEdge(NewTuple(key.First, key.Second, b))
+	}
+	for i := 0; i < num; i++ {
+		graph.AddEdge(NewTuple(num, i, 0))
+	}
+
+	if _, err := NewBellmanFordShortestPath().ShortestPath(graph, num); err != nil {
+		return nil, err
+	}
+	x := make([]int, num)
+	for i := 0; i < num; i++ {
+		x[i] = graph.Vertexes[i].GetKey()
+	}
+	return x, nil
+}
+
+/**
+ * @description: 求解差分约束系统，返回有名字的变量的值，参见Solve
+ */
+func (a *DifferenceConstraints) SolveNamed() (map[string]int, error) {
+	x, err := a.Solve()
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]int{}
+	for name, i := range a.index {
+		values[name] = x[i]
+	}
+	return values, nil
+}
+
+/**
+ * @description: 约束图中的环路对应的约束条件，这些约束条件不能同时满足
+ * @param cycle: Solve返回的*NegativeCycleError
+ * @return: 环路上每条边(i,j)对应的约束条件 x_j - x_i <= b
+ */
+func (a *DifferenceConstraints) CycleConstraints(cycle *NegativeCycleError) []DifferenceConstraint {
+	if cycle == nil {
+		return nil
+	}
+	result := []DifferenceConstraint{}
+	for k := range cycle.Cycle {
+		i, j := cycle.Cycle[k], cycle.Cycle[(k+1)%len(cycle.Cycle)]
+		if b, ok := a.constraints[Pair{First: i, Second: j}]; ok {
+			result = append(result, DifferenceConstraint{J: j, I: i, B: b})
+		}
+	}
+	return result
+}
+
+/**
+ * @description: 约束条件的字符串表示，有名字的变量使用名字
+ */
+func (a *DifferenceConstraints) Format(c DifferenceConstraint) string {
+	name := func(i int) string {
+		if n := a.Name(i); n != "" {
+			return n
+		}
+		return fmt.Sprintf("x%d", i)
+	}
+	return fmt.Sprintf("%s - %s <= %d", name(c.J), name(c.I), c.B)
+}
@@ -47,10 +47,21 @@ import (
 )
 
 type Dijkstra struct {
+	creator PriorityQueueCreator //优先队列的创建函数，为nil时使用MinQueue
 }
 
-func NewDijkstra() *Dijkstra {
-	return &Dijkstra{}
+/**
+ * @description: 创建Dijkstra算法
+ * @param creators: 可选，优先队列的创建函数，例如NewBinaryHeapQueue、NewFibonacciQueue、NewPairingHeapQueue、NewLazyHeapQueue。
+ *		边的权重为较小的非负整数时，还可以使用单调队列NewDialQueue(C)、NewRadixHeapQueue。
+ *		不指定时使用MinQueue，时间复杂度为O(V^2+E)
+ */
+func NewDijkstra(creators ...PriorityQueueCreator) *Dijkstra {
+	a := &Dijkstra{}
+	if len(creators) > 0 {
+		a.creator = creators[0]
+	}
+	return a
 }
 
 /*!
@@ -72,7 +83,15 @@ func NewDijkstra() *Dijkstra {
  *
  * ### 算法性能
  *
- * 时间复杂度为O(V^2+E)
+ * 使用MinQueue时，每次`DecreateKey()`之前都要用`ElementIndex()`线性查找结点的位置，时间复杂度为O(V^2+E)。
+ *
+ * 指定了IndexedPriorityQueue时，队列中只存放已经发现的结点，时间复杂度为：
+ *
+ * - BinaryHeapQueue、LazyHeapQueue：O((V+E)lgV)
+ * - FibonacciQueue：O((E+VlgV)lgV)，data_struct中的斐波那契堆切断孩子节点需要O(lgV)，达不到理论上的O(E+VlgV)
+ * - PairingHeapQueue：O(E+VlgV)左右(减小关键字的平摊代价为o(lgV))
+ * - DialQueue：O(E+VC)，C为边的最大权重；NewDialQueue给出的C偏小时队列会自动扩大
+ * - RadixHeapQueue：O(E+VlgC)
  *
  */
 func (a *Dijkstra) ShortestPath(graph *Graph, source_id int) error {
@@ -89,6 +108,10 @@ func (a *Dijkstra) ShortestPath(graph *Graph, source_id int) error {
 
 	//************* 第一阶段 初始化  ***************
 	a.initializeSingleSource(graph, source_id)
+	if a.creator != nil {
+		a.shortestPathWithQueue(graph, source_id, -1)
+		return nil
+	}
 
 	//************* 第二阶段 构建最小优先队列  ***************
 	//注意，此次可以使用斐波那契堆，能获得更好的性能
@@ -128,6 +151,70 @@ func (a *Dijkstra) ShortestPath(graph *Graph, source_id int) error {
 	return nil
 }
 
+/**
+ * @description: 基于IndexedPriorityQueue的Dijkstra算法，调用前必须已经初始化
+ * @param target_id: 目标结点`id`，目标结点加入集合S后立即停止；为-1时计算整棵最短路径树
+ *
+ * 队列中的元素为结点`id`，关键字为结点的key。结点第一次被松弛时插入队列，之后每次松弛成功都减小它的关键字；
+ * 结点弹出后即加入集合S，不会再被松弛。
+ */
+func (a *Dijkstra) shortestPathWithQueue(graph *Graph, source_id, target_id int) {
+	num := graph.N()
+	settled := make([]bool, num) //settled[id]为true表示结点已加入集合S
+	creator := a.creator
+	if creator == nil {
+		creator = NewBinaryHeapQueue
+	}
+	q := creator(num)
+	q.Push(source_id, 0)
+	for q.Len() > 0 {
+		id, _ := q.Pop()
+		settled[id] = true
+		if id == target_id {
+			return
+		}
+		minNode := graph.Vertexes[id]
+
+		edges, _ := graph.VertexEdgeTuples(id)
+		for _, edge := range edges {
+			other_id := edge.Second
+			if settled[other_id] {
+				continue
+			}
+			other_vtx := graph.Vertexes[other_id]
+			old_key := other_vtx.GetKey()
+			a.relax(minNode, other_vtx, edge.Third)
+			if other_vtx.GetKey() < old_key {
+				q.Push(other_id, other_vtx.GetKey())
+			}
+		}
+	}
+}
+
+/*!
+ * @description: 点到点的最短路径，目标结点加入集合S后立即停止
+ * @param graph:图
+ * @param source_id: 源结点`id`
+ * @param target_id: 目标结点`id`
+ * @return: 最短路径的权重；路径上的结点`id`，从source_id到target_id；目标不可达时返回*UnreachableError
+ *
+ * 总是使用IndexedPriorityQueue(未指定时使用BinaryHeapQueue)。已加入集合S的结点的key和父结点与ShortestPath的结果相同，
+ * 其余结点的key只是最短路径估计值。
+ */
+func (a *Dijkstra) ShortestPathTo(graph *Graph, source_id, target_id int) (int, []int, error) {
+	if err := checkPointToPoint(graph, source_id, target_id); err != nil {
+		return 0, nil, err
+	}
+	a.initializeSingleSource(graph, source_id)
+	a.shortestPathWithQueue(graph, source_id, target_id)
+
+	target := graph.Vertexes[target_id]
+	if Is_Unlimit(target.GetKey()) {
+		return 0, nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+	return target.GetKey(), vertexPath(graph, target_id), nil
+}
+
 func (a *Dijkstra) initializeSingleSource(graph *Graph, source_id int) error {
 	if graph == nil {
 		return errors.New("initializeSingleSource error: graph must not be nil!")
@@ -164,12 +251,14 @@ func (a *Dijkstra) relax(from, to IVertex, weight int) error {
 		return errors.New("relax error: from_vertex must not be to_vertex!")
 	}
 
-	if Is_Unlimit(from.GetKey() + weight) { //u.key+weight为正无穷，则不可能松弛
+	//u.key+weight为正无穷，则不可能松弛。使用饱和加法，正无穷加上负的权重仍为正无穷
+	sum := AddDistance(from.GetKey(), weight)
+	if Is_Unlimit(sum) {
 		return errors.New("distance is max")
 	}
 
-	if to.GetKey() > from.GetKey()+weight {
-		to.SetKey(from.GetKey() + weight)
+	if to.GetKey() > sum {
+		to.SetKey(sum)
 		to.SetParent(from)
 	}
 	return nil
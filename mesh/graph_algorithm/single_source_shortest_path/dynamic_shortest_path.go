@@ -0,0 +1,294 @@
+/*
+ * @Description: 动态单源最短路径：插入、删除边以及修改边的权重之后增量地更新最短路径树
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 22:21:33
+ * @LastEditTime: 2026-10-19 22:21:33
+ * @LastEditors:
+ *
+ *
+ * ## 动态最短路径
+ *
+ * 图中的一条边发生变化之后，从头执行Dijkstra算法需要O((V+E)lgV)的时间，而实际上往往只有很少的结点的最短路径权重会改变。
+ * 本实现采用Ramalingam-Reps算法的思想，只处理受影响的结点，时间复杂度与受影响的结点及其关联的边的数目有关。
+ * 要求所有边的权重非负。
+ *
+ * ### 权重减小或插入边(u,v)
+ *
+ * 若 d(u)+w(u,v)<d(v)，则更新d(v)并把v放入优先队列，然后执行Dijkstra算法：只有最短路径权重减小的结点才会被放入队列，
+ * 其余结点的最短路径权重不变。
+ *
+ * ### 权重增加或删除边(u,v)
+ *
+ * 若(u,v)不是最短路径树中的边，则最短路径树仍然有效，没有结点受影响。否则受影响的结点为最短路径树中以v为根的子树A：
+ *
+ * - 对A中的每个结点x，令 d(x)=min{d(y)+w(y,x):(y,x)属于E，y不属于A}，即只经过A之外的结点到达x的最短路径权重，并把x放入优先队列
+ * - 执行Dijkstra算法，A之外的结点的最短路径权重不会改变，因此只有A中的结点会被松弛
+ * - 仍未被松弛的结点从源结点不可达
+ *
+ * 每次更新返回最短路径权重发生变化的结点，按照`id`升序排列。
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+	"sort"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
+)
+
+type DynamicShortestPath struct {
+	graph   *Graph
+	source  int
+	dist    []int   //dist[v]为源结点到v的最短路径权重，不可达时为Unlimit()
+	parent  []int   //parent[v]为最短路径树中v的父结点，-1表示不存在
+	in      [][]int //in[v]为所有存在边(u,v)的结点u
+	creator PriorityQueueCreator
+	old     map[int]int //本次更新中dist发生变化的结点的原值
+}
+
+/**
+ * @description: 创建动态单源最短路径，并计算初始的最短路径树
+ * @param graph: 图，边的权重必须非负。之后对边的修改都必须通过InsertEdge、DeleteEdge、UpdateEdge进行
+ * @param source_id: 源结点`id`
+ * @param creators: 可选，优先队列的创建函数，不指定时使用NewBinaryHeapQueue
+ */
+func NewDynamicShortestPath(graph *Graph, source_id int, creators ...PriorityQueueCreator) (*DynamicShortestPath, error) {
+	if graph == nil {
+		return nil, errors.New("DynamicShortestPath error: graph must not be nil!")
+	}
+	num := graph.N()
+	if source_id < 0 || source_id >= num || graph.Vertexes[source_id] == nil {
+		return nil, errors.New("DynamicShortestPath error: source_id muse belongs [0,N) and source vertex must not be nil!")
+	}
+	a := &DynamicShortestPath{graph: graph, source: source_id, dist: make([]int, num), parent: make([]int, num),
+		in: make([][]int, num), creator: NewBinaryHeapQueue}
+	if len(creators) > 0 && creators[0] != nil {
+		a.creator = creators[0]
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if edge.Third < 0 {
+			return nil, errors.New("DynamicShortestPath error: edge weight must not be negative!")
+		}
+		a.in[edge.Second] = append(a.in[edge.Second], edge.First)
+	}
+
+	unlimit := Unlimit()
+	for i := 0; i < num; i++ {
+		a.dist[i] = unlimit
+		a.parent[i] = -1
+	}
+	a.old = map[int]int{}
+	q := a.creator(num)
+	a.dist[source_id] = 0
+	q.Push(source_id, 0)
+	a.propagate(q)
+	return a, nil
+}
+
+// 源结点`id`
+func (a *DynamicShortestPath) Source() int {
+	return a.source
+}
+
+/**
+ * @description: 源结点到v的最短路径权重
+ * @return: 最短路径权重；v不可达时返回*UnreachableError
+ */
+func (a *DynamicShortestPath) Dist(v int) (int, error) {
+	if v < 0 || v >= len(a.dist) {
+		return 0, errors.New("Dist error: v must belongs [0,N)!")
+	}
+	if Is_Unlimit(a.dist[v]) {
+		return 0, &UnreachableError{Source: a.source, Target: v}
+	}
+	return a.dist[v], nil
+}
+
+/**
+ * @description: 源结点到v的最短路径，v不可达时返回nil
+ */
+func (a *DynamicShortestPath) Path(v int) []int {
+	if _, err := a.Dist(v); err != nil {
+		return nil
+	}
+	path := []int{}
+	for x := v; x >= 0; x = a.parent[x] {
+		path = append(path, x)
+	}
+	Revert(path)
+	return path
+}
+
+/**
+ * @description: 插入边(u,v)，权重为w
+ * @return: 最短路径权重发生变化的结点；边已存在、权重为负值或者等于图的无效权重时返回error
+ */
+func (a *DynamicShortestPath) InsertEdge(u, v, w int) ([]int, error) {
+	if err := a.checkWeight(w); err != nil {
+		return nil, errors.New("InsertEdge error: " + err.Error())
+	}
+	if has, _ := a.graph.HasEdge(u, v); has {
+		return nil, errors.New("InsertEdge error: edge has already exist!")
+	}
+	if err := a.graph.AddEdge(NewTuple(u, v, w)); err != nil {
+		return nil, err
+	}
+	a.in[v] = append(a.in[v], u)
+	return a.decrease(u, v, w), nil
+}
+
+/**
+ * @description: 删除边(u,v)
+ * @return: 最短路径权重发生变化的结点；边不存在时返回error
+ */
+func (a *DynamicShortestPath) DeleteEdge(u, v int) ([]int, error) {
+	if err := a.graph.RemoveEdge(u, v); err != nil {
+		return nil, err
+	}
+	for i, x := range a.in[v] {
+		if x == u {
+			a.in[v] = append(a.in[v][:i], a.in[v][i+1:]...)
+			break
+		}
+	}
+	return a.increase(u, v), nil
+}
+
+/**
+ * @description: 把边(u,v)的权重修改为w
+ * @return: 最短路径权重发生变化的结点；边不存在、权重为负值或者等于图的无效权重时返回error
+ */
+func (a *DynamicShortestPath) UpdateEdge(u, v, w int) ([]int, error) {
+	if err := a.checkWeight(w); err != nil {
+		return nil, errors.New("UpdateEdge error: " + err.Error())
+	}
+	if has, _ := a.graph.HasEdge(u, v); !has {
+		return nil, errors.New("UpdateEdge error: edge does not exist!")
+	}
+	old, _ := a.graph.Weight(u, v)
+	if err := a.graph.AdjustEdge(u, v, w); err != nil {
+		return nil, err
+	}
+	//Graph.AdjustEdge不返回内部的错误，修改之后再检查一次
+	if weight, err := a.graph.Weight(u, v); err != nil || weight != w {
+		return nil, errors.New("UpdateEdge error: failed to adjust edge weight!")
+	}
+	if w < old {
+		return a.decrease(u, v, w), nil
+	}
+	if w > old {
+		return a.increase(u, v), nil
+	}
+	return []int{}, nil
+}
+
+// 边的权重必须非负，而且不能等于矩阵表示的图的无效权重，否则修改之后边会变为不存在
+func (a *DynamicShortestPath) checkWeight(w int) error {
+	if w < 0 {
+		return errors.New("edge weight must not be negative!")
+	}
+	if a.graph.Matrix != nil && w == a.graph.Matrix.InvalidWeight() {
+		return errors.New("edge weight must not equal to the invalid weight of graph!")
+	}
+	return nil
+}
+
+// 边(u,v)的权重减小为w之后更新
+func (a *DynamicShortestPath) decrease(u, v, w int) []int {
+	a.old = map[int]int{}
+	sum := AddDistance(a.dist[u], w)
+	if Is_Unlimit(sum) || sum >= a.dist[v] {
+		return []int{}
+	}
+	q := a.creator(len(a.dist))
+	a.update(v, sum, u)
+	q.Push(v, a.dist[v])
+	a.propagate(q)
+	return a.changed()
+}
+
+// 边(u,v)的权重增加或者被删除之后更新
+func (a *DynamicShortestPath) increase(u, v int) []int {
+	a.old = map[int]int{}
+	if a.parent[v] != u {
+		return []int{}
+	}
+
+	//************* 受影响的结点：最短路径树中以v为根的子树  ***************
+	num := len(a.dist)
+	children := make([][]int, num)
+	for x, p := range a.parent {
+		if p >= 0 {
+			children[p] = append(children[p], x)
+		}
+	}
+	affected := make([]bool, num)
+	subtree := []int{v}
+	affected[v] = true
+	for i := 0; i < len(subtree); i++ {
+		for _, x := range children[subtree[i]] {
+			affected[x] = true
+			subtree = append(subtree, x)
+		}
+	}
+	unlimit := Unlimit()
+	for _, x := range subtree {
+		a.update(x, unlimit, -1)
+	}
+
+	//************* 只经过A之外的结点到达A中结点的最短路径估计  ***************
+	q := a.creator(num)
+	for _, x := range subtree {
+		for _, y := range a.in[x] {
+			if affected[y] || Is_Unlimit(a.dist[y]) {
+				continue
+			}
+			w, _ := a.graph.Weight(y, x)
+			if sum := AddDistance(a.dist[y], w); sum < a.dist[x] {
+				a.update(x, sum, y)
+			}
+		}
+		if !Is_Unlimit(a.dist[x]) {
+			q.Push(x, a.dist[x])
+		}
+	}
+	a.propagate(q)
+	return a.changed()
+}
+
+// 设置结点x的最短路径权重和父结点，并记录原来的权重
+func (a *DynamicShortestPath) update(x, dist, parent int) {
+	if _, ok := a.old[x]; !ok {
+		a.old[x] = a.dist[x]
+	}
+	a.dist[x] = dist
+	a.parent[x] = parent
+}
+
+// 执行Dijkstra算法直到队列为空
+func (a *DynamicShortestPath) propagate(q IndexedPriorityQueue) {
+	for q.Len() > 0 {
+		x, _ := q.Pop()
+		edges, _ := a.graph.VertexEdgeTuples(x)
+		for _, edge := range edges {
+			if sum := AddDistance(a.dist[x], edge.Third); sum < a.dist[edge.Second] {
+				a.update(edge.Second, sum, x)
+				q.Push(edge.Second, a.dist[edge.Second])
+			}
+		}
+	}
+}
+
+// 本次更新中最短路径权重发生变化的结点
+func (a *DynamicShortestPath) changed() []int {
+	result := []int{}
+	for x, old := range a.old {
+		if old != a.dist[x] {
+			result = append(result, x)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
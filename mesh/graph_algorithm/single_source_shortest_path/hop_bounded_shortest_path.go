@@ -0,0 +1,166 @@
+/*
+ * @Description: 边数受限的单源最短路径，Bellman-Ford算法的变形
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 23:31:46
+ * @LastEditTime: 2026-10-19 23:31:46
+ * @LastEditors:
+ *
+ *
+ * ## 边数受限的最短路径
+ *
+ * 求从源结点s到每个结点v的、最多包含k条边的最短路径。Bellman-Ford算法的第h次处理之后，v.key不大于最多包含h条边的最短路径权重，
+ * 但是由于同一次处理中的松弛会相互影响，v.key对应的路径可能超过h条边。因此这里为每一次处理保存一个独立的数组：
+ *
+ *		D_0(s)=0，D_0(v)=正无穷(v!=s)
+ *		D_h(v)=min{D_(h-1)(v), min{D_(h-1)(u)+w(u,v):(u,v)属于E}}
+ *
+ * D_h(v)就是最多包含h条边的最短路径权重。边的权重可以为负值；存在权重为负值的环路时，得到的是最多包含h条边的路径(可能重复经过结点)。
+ *
+ * 以(边数,权重)为两个目标，若D_h(v)<D_(h-1)(v)，则最多h条边的最短路径是一条Pareto最优路径：边数更少的路径权重都更大。
+ *
+ * 时间复杂度为O(kE)，空间复杂度为O(kV)
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 边数受限的最短路径的结果
+ */
+type HopBoundedTree struct {
+	Source  int
+	MaxHops int
+	dist    [][]int //dist[h][v]=D_h(v)
+	parent  [][]int //parent[h][v]为D_h(v)对应的路径上v的前驱结点，-1表示D_h(v)=D_(h-1)(v)
+}
+
+/**
+ * @description: 源结点到v的最多包含MaxHops条边的最短路径权重
+ * @return: 最短路径权重；不存在最多包含MaxHops条边的路径时返回*UnreachableError
+ */
+func (a *HopBoundedTree) Dist(v int) (int, error) {
+	return a.DistWithHops(v, a.MaxHops)
+}
+
+/**
+ * @description: 源结点到v的最多包含hops条边的最短路径权重，hops不超过MaxHops
+ */
+func (a *HopBoundedTree) DistWithHops(v, hops int) (int, error) {
+	if v < 0 || v >= len(a.dist[0]) || hops < 0 || hops > a.MaxHops {
+		return 0, errors.New("HopBoundedTree error: v must belongs [0,N) and hops must belongs [0,MaxHops]!")
+	}
+	if Is_Unlimit(a.dist[hops][v]) {
+		return 0, &UnreachableError{Source: a.Source, Target: v}
+	}
+	return a.dist[hops][v], nil
+}
+
+/**
+ * @description: 源结点到v的最多包含MaxHops条边的最短路径，不存在时返回nil
+ */
+func (a *HopBoundedTree) Path(v int) []int {
+	return a.PathWithHops(v, a.MaxHops)
+}
+
+/**
+ * @description: 源结点到v的最多包含hops条边的最短路径，不存在时返回nil
+ */
+func (a *HopBoundedTree) PathWithHops(v, hops int) []int {
+	if _, err := a.DistWithHops(v, hops); err != nil {
+		return nil
+	}
+	path := []int{v}
+	for h := hops; h > 0; h-- {
+		if u := a.parent[h][v]; u >= 0 {
+			v = u
+			path = append(path, v)
+		}
+	}
+	Revert(path)
+	return path
+}
+
+/**
+ * @description: 以(权重,边数)为目标，源结点到v的所有Pareto最优路径
+ * @return: Pareto最优路径，Resource为路径的边数，按照边数升序(权重降序)排列
+ */
+func (a *HopBoundedTree) ParetoPaths(v int) []*ParetoPath {
+	result := []*ParetoPath{}
+	if v < 0 || v >= len(a.dist[0]) {
+		return result
+	}
+	for h := 0; h <= a.MaxHops; h++ {
+		if Is_Unlimit(a.dist[h][v]) || h > 0 && a.dist[h][v] >= a.dist[h-1][v] {
+			continue
+		}
+		path := a.PathWithHops(v, h)
+		result = append(result, &ParetoPath{Cost: a.dist[h][v], Resource: len(path) - 1, Path: path})
+	}
+	return result
+}
+
+type HopBoundedShortestPath struct {
+}
+
+func NewHopBoundedShortestPath() *HopBoundedShortestPath {
+	return &HopBoundedShortestPath{}
+}
+
+/*!
+ * @description: 边数受限的单源最短路径
+ * @param graph: 图，边的权重可以为负值
+ * @param source_id: 源结点`id`
+ * @param max_hops: 路径最多包含的边数k
+ * @return: 结果；参数无效时返回error。不修改顶点的key和父结点
+ *
+ * ### 算法步骤
+ *
+ * - 初始化D_0
+ * - 进行k次处理，第h次处理先令D_h=D_(h-1)，然后对每条边(u,v)用D_(h-1)(u)+w(u,v)松弛D_h(v)
+ */
+func (a *HopBoundedShortestPath) ShortestPath(graph *Graph, source_id, max_hops int) (*HopBoundedTree, error) {
+	if graph == nil {
+		return nil, errors.New("HopBoundedShortestPath error: graph must not be nil!")
+	}
+	num := graph.N()
+	if source_id < 0 || source_id >= num || graph.Vertexes[source_id] == nil {
+		return nil, errors.New("HopBoundedShortestPath error: source_id muse belongs [0,N) and source vertex must not be nil!")
+	}
+	if max_hops < 0 {
+		return nil, errors.New("HopBoundedShortestPath error: max_hops must not be negative!")
+	}
+
+	tree := &HopBoundedTree{Source: source_id, MaxHops: max_hops, dist: make([][]int, max_hops+1), parent: make([][]int, max_hops+1)}
+	tree.dist[0] = make([]int, num)
+	tree.parent[0] = make([]int, num)
+	for v := 0; v < num; v++ {
+		tree.dist[0][v] = Unlimit()
+		tree.parent[0][v] = -1
+	}
+	tree.dist[0][source_id] = 0
+
+	edges := graph.EdgeTuples()
+	for h := 1; h <= max_hops; h++ {
+		prev := tree.dist[h-1]
+		dist := make([]int, num)
+		parent := make([]int, num)
+		copy(dist, prev)
+		for v := range parent {
+			parent[v] = -1
+		}
+		for _, edge := range edges {
+			if sum := AddDistance(prev[edge.First], edge.Third); sum < dist[edge.Second] {
+				dist[edge.Second] = sum
+				parent[edge.Second] = edge.First
+			}
+		}
+		tree.dist[h] = dist
+		tree.parent[h] = parent
+	}
+	return tree, nil
+}
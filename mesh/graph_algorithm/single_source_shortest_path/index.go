@@ -0,0 +1,77 @@
+/*
+ * @Description: 单源最短路径的公共函数和错误类型
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 18:42:15
+ * @LastEditTime: 2026-10-19 18:42:15
+ * @LastEditors:
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 目标结点从源结点不可达
+ */
+type UnreachableError struct {
+	Source int //源结点`id`
+	Target int //目标结点`id`
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("shortest path error: vertex %d is unreachable from vertex %d!", e.Target, e.Source)
+}
+
+/**
+ * @description: 图中存在权重为负值的环路
+ */
+type NegativeCycleError struct {
+	Cycle  []int //环路上的结点`id`，依次存在边Cycle[0]-->Cycle[1]-->...-->Cycle[k-1]-->Cycle[0]
+	Weight int   //环路的权重，为负值
+}
+
+func (e *NegativeCycleError) Error() string {
+	return fmt.Sprintf("shortest path error: graph contains a negative-weight cycle %v with weight %d!", e.Cycle, e.Weight)
+}
+
+/**
+ * @description: 沿着父结点从target_id回溯到树根，得到最短路径树上从树根到target_id的路径
+ * @return: 路径上的结点`id`，从树根到target_id
+ */
+func vertexPath(graph *Graph, target_id int) []int {
+	path := []int{}
+	for v := graph.Vertexes[target_id]; v != nil; v = v.GetParent() {
+		path = append(path, v.GetID())
+	}
+	Revert(path)
+	return path
+}
+
+/**
+ * @description: ShortestPathTo的参数检查
+ */
+func checkPointToPoint(graph *Graph, source_id, target_id int) error {
+	if graph == nil {
+		return errors.New("ShortestPathTo error: graph must not be nil!")
+	}
+	num := graph.N()
+	if source_id < 0 || source_id >= num || graph.Vertexes[source_id] == nil ||
+		target_id < 0 || target_id >= num || graph.Vertexes[target_id] == nil {
+		return errors.New("ShortestPathTo error: source_id and target_id must belongs [0,N) and vertexes must not be nil!")
+	}
+	return nil
+}
+
+/**
+ * @description: 双目标最短路径问题中的一条Pareto最优路径：不存在另一条路径的Cost和Resource都不大于它且至少有一个更小
+ */
+type ParetoPath struct {
+	Cost     int   //路径的权重之和
+	Resource int   //路径消耗的第二种资源，例如边数、时间
+	Path     []int //路径上的结点`id`，从源结点到目标结点
+}
@@ -0,0 +1,411 @@
+/*
+ * @Description: 两个结点之间的前k条最短路径：Yen算法与Eppstein风格的算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 19:02:37
+ * @LastEditTime: 2026-10-19 19:02:37
+ * @LastEditors:
+ *
+ *
+ * 给定带非负权重的有向图G=(V,E)、源结点s和目标结点t，求出s到t的权重最小的k条路径，按照权重从小到大排列。
+ *
+ * ## Yen算法(无环路径)
+ *
+ * 设A[0]为s到t的最短路径。求第k条路径时，依次以A[k-1]上的每个结点(最后一个结点除外)作为偏离结点spur：
+ *
+ * - 根路径root为A[k-1]上从s到spur的部分
+ * - 对于已经求出的、以root为前缀的每条路径，删除它从spur出发的那条边，避免重复
+ * - 删除root上除spur以外的所有结点，保证路径无环
+ * - 用Dijkstra算法求出spur到t的最短路径spur_path，root+spur_path作为候选路径放入集合B
+ *
+ * 然后从B中取出权重最小的候选路径作为A[k]。"删除"的边和结点只记录在集合中，在图的邻接表快照上执行Dijkstra算法时跳过它们，
+ * 不修改输入的图。
+ *
+ * 时间复杂度：O(kV)次Dijkstra算法
+ *
+ * ## Eppstein风格的算法(允许有环路径)
+ *
+ * 用Dijkstra算法在反向图上求出每个结点v到t的最短路径权重d(v)以及最短路径树T。对于不在T中的边(u,v)，称为侧边，
+ * 它的代价增量为 delta(u,v)=w(u,v)+d(v)-d(u)>=0。s到t的任意一条路径都可以唯一地表示为它依次经过的侧边序列，
+ * 路径的权重为d(s)加上这些侧边的代价增量之和。
+ *
+ * 对每个结点v，令L(v)为T中从v到t的路径上所有结点出发的侧边，按照代价增量排序。以侧边序列为结点构造一棵搜索树：
+ * 序列(P,L(h)[i])的孩子为 (P,L(h)[i+1]) 以及 (P+L(h)[i],L(head)[0])，其中h为P中最后一条侧边的终点，head为L(h)[i]的终点。
+ * 每个孩子的代价都不小于父亲，因此用最小优先队列对这棵树做最佳优先搜索，就能按照权重从小到大依次得到所有路径。
+ *
+ * 该算法得到的路径可能包含重复的结点。Eppstein的原始算法用可持久化的堆表示L(v)，这里用有序数组代替。
+ *
+ * 时间复杂度：一次Dijkstra算法，加上O(k lgk)的搜索，再加上构造L(v)和路径的代价
+ */
+package SingleSourceShortestPath
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
+)
+
+type KShortestPathMode int
+
+const (
+	K_SHORTEST_YEN      KShortestPathMode = iota //Yen算法，只返回无环路径
+	K_SHORTEST_EPPSTEIN                          //Eppstein风格的算法，返回的路径可能有环
+)
+
+/**
+ * @description: 带权重的路径
+ */
+type WeightedPath struct {
+	Cost int   //路径的权重
+	Path []int //路径上的结点`id`，从源结点到目标结点
+}
+
+type KShortestPath struct {
+	Mode    KShortestPathMode
+	creator PriorityQueueCreator
+}
+
+/**
+ * @description: 创建前k条最短路径算法
+ * @param mode: K_SHORTEST_YEN或者K_SHORTEST_EPPSTEIN
+ * @param creators: 可选，内部Dijkstra算法使用的优先队列的创建函数，不指定时使用NewBinaryHeapQueue
+ */
+func NewKShortestPath(mode KShortestPathMode, creators ...PriorityQueueCreator) *KShortestPath {
+	a := &KShortestPath{Mode: mode, creator: NewBinaryHeapQueue}
+	if len(creators) > 0 && creators[0] != nil {
+		a.creator = creators[0]
+	}
+	return a
+}
+
+/*!
+ * @description: 求source_id到target_id的前k条最短路径
+ * @param graph: 有向图，边的权重必须非负
+ * @param source_id: 源结点`id`
+ * @param target_id: 目标结点`id`
+ * @param k: 路径的条数
+ * @return: 按照权重从小到大排列的路径，不足k条时返回所有路径；目标不可达时返回*UnreachableError
+ *
+ * 不修改图中的边，也不修改顶点的key和父结点
+ */
+func (a *KShortestPath) Paths(graph *Graph, source_id, target_id, k int) ([]*WeightedPath, error) {
+	if err := checkPointToPoint(graph, source_id, target_id); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, errors.New("KShortestPath error: k must be positive!")
+	}
+	for _, edge := range graph.EdgeTuples() {
+		if edge.Third < 0 {
+			return nil, errors.New("KShortestPath error: edge weight must not be negative!")
+		}
+	}
+	if a.Mode == K_SHORTEST_EPPSTEIN {
+		return a.eppstein(graph, source_id, target_id, k)
+	}
+	return a.yen(graph, source_id, target_id, k)
+}
+
+/**
+ * @description: Yen算法
+ */
+func (a *KShortestPath) yen(graph *Graph, source_id, target_id, k int) ([]*WeightedPath, error) {
+	num := graph.N()
+	adj := make([][]*Tuple, num) //图的邻接表快照
+	for id := 0; id < num; id++ {
+		if graph.Vertexes[id] != nil {
+			adj[id], _ = graph.VertexEdgeTuples(id)
+		}
+	}
+	banned_vertexes := make([]bool, num)
+	cost, path, found := a.spurSearch(adj, source_id, target_id, map[Pair]bool{}, banned_vertexes)
+	if !found {
+		return nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+	result := []*WeightedPath{{Cost: cost, Path: path}}
+	candidates := &weightedPathHeap{}
+	seen := map[string]bool{pathKey(path): true}
+
+	for len(result) < k {
+		prev := result[len(result)-1].Path
+		root_cost := 0
+		for i := 0; i < len(prev)-1; i++ {
+			spur := prev[i]
+			root := prev[:i+1]
+
+			//*********** 删除已求出的、以root为前缀的路径从spur出发的边，以及根路径上除spur以外的结点 ****************
+			banned_edges := map[Pair]bool{}
+			for _, p := range result {
+				if len(p.Path) > i+1 && samePrefix(p.Path, root) {
+					banned_edges[Pair{First: p.Path[i], Second: p.Path[i+1]}] = true
+				}
+			}
+			for _, id := range root[:i] {
+				banned_vertexes[id] = true
+			}
+			spur_cost, spur_path, found := a.spurSearch(adj, spur, target_id, banned_edges, banned_vertexes)
+			for _, id := range root[:i] {
+				banned_vertexes[id] = false
+			}
+
+			if cost := AddDistance(root_cost, spur_cost); found && !Is_Unlimit(cost) {
+				candidate := append(append([]int{}, root[:i]...), spur_path...)
+				key := pathKey(candidate)
+				if !seen[key] {
+					seen[key] = true
+					heap.Push(candidates, &WeightedPath{Cost: cost, Path: candidate})
+				}
+			}
+			weight, _ := graph.Weight(prev[i], prev[i+1])
+			root_cost = AddDistance(root_cost, weight)
+		}
+		if candidates.Len() == 0 {
+			break
+		}
+		result = append(result, heap.Pop(candidates).(*WeightedPath))
+	}
+	return result, nil
+}
+
+/**
+ * @description: 在邻接表快照上执行点到点的Dijkstra算法，跳过被删除的边和结点
+ * @return: 最短路径权重；路径上的结点`id`；target_id是否可达
+ */
+func (a *KShortestPath) spurSearch(adj [][]*Tuple, source_id, target_id int, banned_edges map[Pair]bool, banned_vertexes []bool) (int, []int, bool) {
+	num := len(adj)
+	dist := make([]int, num)
+	parent := make([]int, num)
+	for v := 0; v < num; v++ {
+		dist[v] = Unlimit()
+		parent[v] = -1
+	}
+	q := a.creator(num)
+	dist[source_id] = 0
+	q.Push(source_id, 0)
+	for q.Len() > 0 {
+		u, _ := q.Pop()
+		if u == target_id {
+			break
+		}
+		for _, edge := range adj[u] {
+			v := edge.Second
+			if banned_vertexes[v] || banned_edges[Pair{First: u, Second: v}] {
+				continue
+			}
+			if sum := AddDistance(dist[u], edge.Third); !Is_Unlimit(sum) && sum < dist[v] {
+				dist[v] = sum
+				parent[v] = u
+				q.Push(v, sum)
+			}
+		}
+	}
+	if Is_Unlimit(dist[target_id]) {
+		return 0, nil, false
+	}
+	path := []int{}
+	for x := target_id; x >= 0; x = parent[x] {
+		path = append(path, x)
+	}
+	Revert(path)
+	return dist[target_id], path, true
+}
+
+/**
+ * @description: Eppstein风格的算法
+ */
+func (a *KShortestPath) eppstein(graph *Graph, source_id, target_id, k int) ([]*WeightedPath, error) {
+	num := graph.N()
+
+	//*********** 在反向图上求每个结点到target_id的最短路径树 ****************
+	inverse := graph.Inverse()
+	NewDijkstra(a.creator).ShortestPath(inverse, target_id)
+	dist := make([]int, num)
+	next := make([]int, num) //next[v]为最短路径树上v的下一个结点，-1表示v为target_id或者不可达
+	for v := 0; v < num; v++ {
+		dist[v] = Unlimit()
+		next[v] = -1
+		if vertex := inverse.Vertexes[v]; vertex != nil {
+			dist[v] = vertex.GetKey()
+			if parent := vertex.GetParent(); parent != nil {
+				next[v] = parent.GetID()
+			}
+		}
+	}
+	if Is_Unlimit(dist[source_id]) {
+		return nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+
+	//*********** 侧边 ****************
+	sidetracks := make([][]*eppsteinSidetrack, num)
+	for _, edge := range graph.EdgeTuples() {
+		u, v := edge.First, edge.Second
+		if Is_Unlimit(dist[u]) || Is_Unlimit(dist[v]) {
+			continue
+		}
+		sum := AddDistance(edge.Third, dist[v])
+		if Is_Unlimit(sum) || next[u] == v && dist[u] == sum {
+			continue //和为正无穷的边或者树边
+		}
+		sidetracks[u] = append(sidetracks[u], &eppsteinSidetrack{from: u, to: v, delta: sum - dist[u]})
+	}
+	//lists[v]为L(v)，按需构造：L(v)由v的侧边与L(next[v])归并得到
+	lists := make([][]*eppsteinSidetrack, num)
+	built := make([]bool, num)
+	list := func(v int) []*eppsteinSidetrack {
+		//沿树向下找到第一个已构造的结点，再自底向上构造，避免递归过深
+		chain := []int{}
+		for x := v; x >= 0 && !built[x]; x = next[x] {
+			chain = append(chain, x)
+		}
+		for i := len(chain) - 1; i >= 0; i-- {
+			x := chain[i]
+			own := sidetracks[x]
+			sort.SliceStable(own, func(p, q int) bool { return own[p].delta < own[q].delta })
+			var tail []*eppsteinSidetrack
+			if next[x] >= 0 {
+				tail = lists[next[x]]
+			}
+			lists[x] = mergeSidetracks(own, tail)
+			built[x] = true
+		}
+		return lists[v]
+	}
+
+	//*********** 最佳优先搜索 ****************
+	result := []*WeightedPath{}
+	queue := &eppsteinHeap{}
+	heap.Push(queue, &eppsteinNode{cost: dist[source_id], index: -1})
+	for queue.Len() > 0 && len(result) < k {
+		node := heap.Pop(queue).(*eppsteinNode)
+		result = append(result, &WeightedPath{Cost: node.cost, Path: eppsteinPath(node, source_id, next)})
+
+		head := source_id
+		if node.index >= 0 {
+			head = node.sidetrack.to
+			if siblings := list(node.list_vertex); node.index+1 < len(siblings) { //(P,L(h)[i+1])
+				sibling := siblings[node.index+1]
+				if cost := AddDistance(node.cost-node.sidetrack.delta, sibling.delta); !Is_Unlimit(cost) {
+					heap.Push(queue, &eppsteinNode{cost: cost,
+						parent: node.parent, list_vertex: node.list_vertex, index: node.index + 1, sidetrack: sibling})
+				}
+			}
+		}
+		if children := list(head); len(children) > 0 { //(P+L(h)[i],L(head)[0])
+			if cost := AddDistance(node.cost, children[0].delta); !Is_Unlimit(cost) {
+				heap.Push(queue, &eppsteinNode{cost: cost,
+					parent: node, list_vertex: head, index: 0, sidetrack: children[0]})
+			}
+		}
+	}
+	return result, nil
+}
+
+type eppsteinSidetrack struct {
+	from, to int
+	delta    int //代价增量
+}
+
+// 归并两个按照代价增量排序的侧边列表
+func mergeSidetracks(x, y []*eppsteinSidetrack) []*eppsteinSidetrack {
+	result := make([]*eppsteinSidetrack, 0, len(x)+len(y))
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		if y[j].delta < x[i].delta {
+			result = append(result, y[j])
+			j++
+		} else {
+			result = append(result, x[i])
+			i++
+		}
+	}
+	result = append(result, x[i:]...)
+	return append(result, y[j:]...)
+}
+
+/**
+ * @description: 搜索树的结点，表示侧边序列：parent表示的序列再加上sidetrack=L(list_vertex)[index]；index为-1表示空序列，即最短路径
+ */
+type eppsteinNode struct {
+	cost        int
+	parent      *eppsteinNode
+	list_vertex int
+	index       int
+	sidetrack   *eppsteinSidetrack
+}
+
+// 由侧边序列还原出路径：从source_id出发沿最短路径树前进，遇到下一条侧边的起点时走侧边
+func eppsteinPath(node *eppsteinNode, source_id int, next []int) []int {
+	edges := []*eppsteinSidetrack{}
+	for x := node; x != nil && x.index >= 0; x = x.parent {
+		edges = append(edges, x.sidetrack)
+	}
+	path := []int{source_id}
+	cur := source_id
+	for i := len(edges) - 1; i >= 0; i-- {
+		for cur != edges[i].from {
+			cur = next[cur]
+			path = append(path, cur)
+		}
+		cur = edges[i].to
+		path = append(path, cur)
+	}
+	for next[cur] >= 0 {
+		cur = next[cur]
+		path = append(path, cur)
+	}
+	return path
+}
+
+type eppsteinHeap []*eppsteinNode
+
+func (h eppsteinHeap) Len() int            { return len(h) }
+func (h eppsteinHeap) Less(i, j int) bool  { return h[i].cost < h[j].cost }
+func (h eppsteinHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *eppsteinHeap) Push(x interface{}) { *h = append(*h, x.(*eppsteinNode)) }
+func (h *eppsteinHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// 候选路径的最小堆，权重相同时按照路径的字典序排列，保证结果确定
+type weightedPathHeap []*WeightedPath
+
+func (h weightedPathHeap) Len() int { return len(h) }
+func (h weightedPathHeap) Less(i, j int) bool {
+	if h[i].Cost != h[j].Cost {
+		return h[i].Cost < h[j].Cost
+	}
+	for p := 0; p < len(h[i].Path) && p < len(h[j].Path); p++ {
+		if h[i].Path[p] != h[j].Path[p] {
+			return h[i].Path[p] < h[j].Path[p]
+		}
+	}
+	return len(h[i].Path) < len(h[j].Path)
+}
+func (h weightedPathHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *weightedPathHeap) Push(x interface{}) { *h = append(*h, x.(*WeightedPath)) }
+func (h *weightedPathHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func samePrefix(path, prefix []int) bool {
+	for i, id := range prefix {
+		if path[i] != id {
+			return false
+		}
+	}
+	return true
+}
+
+func pathKey(path []int) string {
+	return fmt.Sprint(path)
+}
@@ -0,0 +1,232 @@
+/*
+ * @Description: 算法导论思考题24-5 Karp的最小平均权重环路算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 23:05:12
+ * @LastEditTime: 2026-10-19 23:05:12
+ * @LastEditors:
+ *
+ *
+ * ## 最小平均权重环路
+ *
+ * 给定带权重的有向图G=(V,E)，环路c=<e1,e2,...,ek>的平均权重为 mu(c)=(w(e1)+w(e2)+...+w(ek))/k。令 mu*=min{mu(c)}，
+ * 平均权重等于mu*的环路称为最小平均权重环路。
+ *
+ * ## Karp算法
+ *
+ * 设G是强连通的，有n个结点，任选一个结点s作为源结点。令 D_k(v) 为从s到v的恰好包含k条边的路径(可以重复经过结点)的最小权重，
+ * 不存在这样的路径时为正无穷。D_k 可以用动态规划计算：D_0(s)=0，D_k(v)=min{D_(k-1)(u)+w(u,v):(u,v)属于E}。则
+ *
+ *		mu* = min_v max_(0<=k<n) (D_n(v)-D_k(v))/(n-k)
+ *
+ * 其中只考虑D_n(v)和D_k(v)有限的项。
+ *
+ * 环路的构造：设v*取得外层的最小值。把所有边的权重减去mu*之后不存在权重为负值的环路，而且从s到v*的恰好包含n条边的最短路径W的权重等于
+ * s到v*的最短路径权重。W包含n条边，必然经过某个结点两次；从W中剪掉一个简单环路c之后剩下的仍然是s到v*的路径，所以c的权重(减去mu*之后)为0，
+ * 即c的平均权重为mu*。
+ *
+ * 对于一般的有向图，每个环路都位于某个强连通分量中，因此对每个强连通分量分别执行Karp算法，取最小值即可。
+ *
+ * 时间复杂度为O(VE)
+ */
+package SingleSourceShortestPath
+
+import (
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/basic_graph"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+)
+
+/**
+ * @description: 一个环路及其平均权重
+ */
+type MeanCycle struct {
+	Weight int   //环路的权重
+	Length int   //环路的边数
+	Cycle  []int //环路上的结点`id`，依次存在边Cycle[0]-->Cycle[1]-->...-->Cycle[k-1]-->Cycle[0]
+}
+
+/**
+ * @description: 环路的平均权重 Weight/Length
+ */
+func (a *MeanCycle) Mean() float64 {
+	return float64(a.Weight) / float64(a.Length)
+}
+
+/**
+ * @description: 平均权重是否小于另一个环路，用整数运算精确比较
+ */
+func (a *MeanCycle) Less(other *MeanCycle) bool {
+	return a.Weight*other.Length < other.Weight*a.Length
+}
+
+type MinimumMeanCycle struct {
+}
+
+func NewMinimumMeanCycle() *MinimumMeanCycle {
+	return &MinimumMeanCycle{}
+}
+
+/**
+ * @description: 求图中的最小平均权重环路
+ * @param graph: 有向图，不要求强连通
+ * @return: 最小平均权重环路；graph为空或者图中不存在环路时返回error
+ */
+func (a *MinimumMeanCycle) Solve(graph *Graph) (*MeanCycle, error) {
+	cycles, err := a.SolveComponents(graph)
+	if err != nil {
+		return nil, err
+	}
+	if len(cycles) == 0 {
+		return nil, errors.New("MinimumMeanCycle error: graph must contain a cycle!")
+	}
+	best := cycles[0]
+	for _, cycle := range cycles[1:] {
+		if cycle.Less(best) {
+			best = cycle
+		}
+	}
+	return best, nil
+}
+
+/*!
+ * @description: 对每个包含环路的强连通分量，求其中的最小平均权重环路
+ * @param graph: 有向图
+ * @return: 每个包含环路的强连通分量的最小平均权重环路；graph为空时返回error
+ *
+ * ### 算法步骤
+ *
+ * - 强连通分量算法要求结点为DFSVertex，所以在图的拷贝上求强连通分量。只有一个结点的强连通分量只有在存在自环时才包含环路
+ * - 对每个强连通分量，只保留两端都在其中的边，执行Karp算法
+ */
+func (a *MinimumMeanCycle) SolveComponents(graph *Graph) ([]*MeanCycle, error) {
+	if graph == nil {
+		return nil, errors.New("MinimumMeanCycle error: graph must not be nil!")
+	}
+	num := graph.N()
+	copied := NewGraph(0, num, func(key, id int) IVertex {
+		return NewDFSVertex(key, id)
+	}, GRAPH_REPRESENTION_ADJ)
+	for i, vertex := range graph.Vertexes {
+		if vertex != nil {
+			copied.AddVertex(0, i)
+		}
+	}
+	edges := graph.EdgeTuples()
+	copied.AddEdges(edges)
+	components, err := (&StrongConnectedComponent{}).SetStrongConnectedComponent(copied)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*MeanCycle{}
+	in_component := make([]bool, num)
+	for _, component := range components {
+		for _, id := range component {
+			in_component[id] = true
+		}
+		result = append(result, a.karp(graph, component))
+	}
+	for _, edge := range edges {
+		if edge.First == edge.Second && !in_component[edge.First] {
+			result = append(result, &MeanCycle{Weight: edge.Third, Length: 1, Cycle: []int{edge.First}})
+		}
+	}
+	return result, nil
+}
+
+/**
+ * @description: 在强连通分量上执行Karp算法
+ * @param graph: 图
+ * @param component: 强连通分量中的结点`id`，至少包含两个结点
+ * @return: 强连通分量中的最小平均权重环路
+ */
+func (a *MinimumMeanCycle) karp(graph *Graph, component []int) *MeanCycle {
+	n := len(component)
+	local := make(map[int]int, n) //结点`id`在强连通分量中的下标
+	for i, id := range component {
+		local[id] = i
+	}
+	type localEdge struct{ from, to, weight int }
+	edges := []localEdge{}
+	for i, id := range component {
+		out, _ := graph.VertexEdgeTuples(id)
+		for _, edge := range out {
+			if j, ok := local[edge.Second]; ok {
+				edges = append(edges, localEdge{i, j, edge.Third})
+			}
+		}
+	}
+
+	//************* D[k][v]：从下标为0的结点出发，恰好经过k条边到达v的最小权重  ***************
+	unlimit := Unlimit()
+	D := make([][]int, n+1)
+	parent := make([][]int, n+1)
+	for k := range D {
+		D[k] = make([]int, n)
+		parent[k] = make([]int, n)
+		for v := range D[k] {
+			D[k][v] = unlimit
+			parent[k][v] = -1
+		}
+	}
+	D[0][0] = 0
+	for k := 1; k <= n; k++ {
+		for _, edge := range edges {
+			if sum := AddDistance(D[k-1][edge.from], edge.weight); sum < D[k][edge.to] {
+				D[k][edge.to] = sum
+				parent[k][edge.to] = edge.from
+			}
+		}
+	}
+
+	//************* mu* = min_v max_k (D_n(v)-D_k(v))/(n-k)，分数用(分子,分母)表示  ***************
+	best_v, best_num, best_den := -1, 0, 1
+	for v := 0; v < n; v++ {
+		if Is_Unlimit(D[n][v]) {
+			continue
+		}
+		max_num, max_den := 0, 0
+		for k := 0; k < n; k++ {
+			if Is_Unlimit(D[k][v]) {
+				continue
+			}
+			num, den := D[n][v]-D[k][v], n-k
+			if max_den == 0 || num*max_den > max_num*den {
+				max_num, max_den = num, den
+			}
+		}
+		if max_den > 0 && (best_v < 0 || max_num*best_den < best_num*max_den) {
+			best_v, best_num, best_den = v, max_num, max_den
+		}
+	}
+
+	//************* 沿着前驱找出恰好包含n条边的路径，其中第一个重复的结点确定一个简单环路  ***************
+	walk := make([]int, n+1) //walk[k]为路径上第k条边之后的结点
+	walk[n] = best_v
+	for k := n; k > 0; k-- {
+		walk[k-1] = parent[k][walk[k]]
+	}
+	seen := make([]int, n) //seen[v]为结点v在walk中最后一次出现的位置，-1表示未出现
+	for i := range seen {
+		seen[i] = -1
+	}
+	begin, end := 0, 0
+	for k := n; k >= 0; k-- {
+		if seen[walk[k]] >= 0 {
+			begin, end = k, seen[walk[k]]
+			break
+		}
+		seen[walk[k]] = k
+	}
+
+	cycle := &MeanCycle{Length: end - begin}
+	for k := begin; k < end; k++ {
+		cycle.Cycle = append(cycle.Cycle, component[walk[k]])
+		w, _ := graph.Weight(component[walk[k]], component[walk[k+1]])
+		cycle.Weight += w
+	}
+	return cycle
+}
@@ -0,0 +1,178 @@
+/*
+ * @Description: 资源受限的最短路径：双目标标号设定(label-setting)算法
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 23:48:05
+ * @LastEditTime: 2026-10-19 23:48:05
+ * @LastEditors:
+ *
+ *
+ * ## 资源受限的最短路径
+ *
+ * 每条边(u,v)除了权重w(u,v)(例如费用)之外，还消耗另一种资源r(u,v)(例如时间)。路径p的两个目标为c(p)=sum{w(e)}和r(p)=sum{r(e)}。
+ * 若c(p)<=c(q)且r(p)<=r(q)，并且至少一个不等式严格成立，则称p支配q。不被任何路径支配的路径为Pareto最优路径。
+ * 给定资源预算B时，只考虑r(p)<=B的路径，其中c(p)最小的路径即为资源受限的最短路径(该问题是NP难的，标号的数目在最坏情况下是指数级的)。
+ *
+ * ## 标号设定算法
+ *
+ * 标号(c,r,v)表示一条从源结点到v、费用为c、资源消耗为r的路径。要求w和r都非负，这是Dijkstra算法在多目标情况下的推广：
+ *
+ * - 优先队列按照(c,r)的字典序取出最小的标号(c,r,v)
+ * - 由于取出的顺序是字典序，v上已经确定的标号的费用都不大于c。因此(c,r,v)被支配当且仅当r不小于v上已经确定的标号的最小资源消耗，
+ *   此时丢弃该标号
+ * - 否则确定该标号，并沿着v的每条出边(v,x)扩展出标号(c+w(v,x),r+r(v,x),x)；超过预算或者已经被x上确定的标号支配的新标号直接丢弃
+ *
+ * 目标结点上确定的所有标号按照费用升序、资源消耗降序排列，恰好是全部的Pareto最优路径。
+ */
+package SingleSourceShortestPath
+
+import (
+	"container/heap"
+	"errors"
+
+	. "github.com/meshcross/algorithm-3rd/mesh/common"
+	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
+)
+
+/**
+ * @description: 边(from_id,to_id)消耗的第二种资源
+ */
+type EdgeResourceFunc func(from_id, to_id int) int
+
+/**
+ * @description: 从另一个图中读取资源：resource中边(u,v)的权重为边(u,v)消耗的资源。resource中不存在的边消耗0
+ */
+func GraphResource(resource *Graph) EdgeResourceFunc {
+	return func(from_id, to_id int) int {
+		if has, _ := resource.HasEdge(from_id, to_id); !has {
+			return 0
+		}
+		r, _ := resource.Weight(from_id, to_id)
+		return r
+	}
+}
+
+type ResourceConstrainedShortestPath struct {
+	Budget int //资源预算，只考虑资源消耗不超过Budget的路径；小于0表示不限制
+}
+
+/**
+ * @description: 创建资源受限的最短路径算法
+ * @param budget: 资源预算，小于0表示不限制
+ */
+func NewResourceConstrainedShortestPath(budget int) *ResourceConstrainedShortestPath {
+	return &ResourceConstrainedShortestPath{Budget: budget}
+}
+
+/*!
+ * @description: 标号设定算法，求source_id到target_id的所有资源消耗不超过预算的Pareto最优路径
+ * @param graph: 图，边的权重必须非负
+ * @param resource: 边消耗的资源，必须非负
+ * @param source_id: 源结点`id`
+ * @param target_id: 目标结点`id`
+ * @return: Pareto最优路径，按照费用升序(资源消耗降序)排列，目标不可达时为空；参数无效时返回error。不修改顶点的key和父结点
+ */
+func (a *ResourceConstrainedShortestPath) ParetoPaths(graph *Graph, resource EdgeResourceFunc, source_id, target_id int) ([]*ParetoPath, error) {
+	if err := checkPointToPoint(graph, source_id, target_id); err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.New("ResourceConstrainedShortestPath error: resource must not be nil!")
+	}
+
+	num := graph.N()
+	labels := []rcLabel{{cost: 0, resource: 0, vertex: source_id, parent: -1}}
+	min_resource := make([]int, num) //min_resource[v]为v上已经确定的标号的最小资源消耗
+	for v := range min_resource {
+		min_resource[v] = Unlimit()
+	}
+	settled := []int{} //目标结点上确定的标号
+	queue := &rcHeap{{cost: 0, resource: 0, index: 0}}
+	for queue.Len() > 0 {
+		index := heap.Pop(queue).(rcItem).index
+		label := labels[index]
+		v := label.vertex
+		if label.resource >= min_resource[v] {
+			continue
+		}
+		min_resource[v] = label.resource
+		if v == target_id {
+			settled = append(settled, index)
+			continue //经过目标结点再回到目标结点的路径必然被支配
+		}
+		edges, _ := graph.VertexEdgeTuples(v)
+		for _, edge := range edges {
+			if edge.Third < 0 {
+				return nil, errors.New("ResourceConstrainedShortestPath error: edge weight must not be negative!")
+			}
+			r := resource(v, edge.Second)
+			if r < 0 {
+				return nil, errors.New("ResourceConstrainedShortestPath error: edge resource must not be negative!")
+			}
+			next := rcLabel{cost: AddDistance(label.cost, edge.Third), resource: AddDistance(label.resource, r),
+				vertex: edge.Second, parent: index}
+			if Is_Unlimit(next.cost) || a.Budget >= 0 && next.resource > a.Budget || next.resource >= min_resource[next.vertex] {
+				continue
+			}
+			labels = append(labels, next)
+			heap.Push(queue, rcItem{cost: next.cost, resource: next.resource, index: len(labels) - 1})
+		}
+	}
+
+	result := make([]*ParetoPath, 0, len(settled))
+	for _, index := range settled {
+		path := []int{}
+		for i := index; i >= 0; i = labels[i].parent {
+			path = append(path, labels[i].vertex)
+		}
+		Revert(path)
+		result = append(result, &ParetoPath{Cost: labels[index].cost, Resource: labels[index].resource, Path: path})
+	}
+	return result, nil
+}
+
+/**
+ * @description: 资源消耗不超过预算的费用最小的路径
+ * @return: 路径的费用；路径上的结点`id`，从source_id到target_id；不存在满足预算的路径时返回*UnreachableError
+ */
+func (a *ResourceConstrainedShortestPath) ShortestPathTo(graph *Graph, resource EdgeResourceFunc, source_id, target_id int) (int, []int, error) {
+	paths, err := a.ParetoPaths(graph, resource, source_id, target_id)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(paths) == 0 {
+		return 0, nil, &UnreachableError{Source: source_id, Target: target_id}
+	}
+	return paths[0].Cost, paths[0].Path, nil
+}
+
+type rcLabel struct {
+	cost     int
+	resource int
+	vertex   int
+	parent   int //扩展出该标号的标号的下标，-1表示源结点的标号
+}
+
+// 标号组成的最小堆，按照(cost,resource)的字典序
+type rcItem struct {
+	cost     int
+	resource int
+	index    int //标号的下标
+}
+
+type rcHeap []rcItem
+
+func (h rcHeap) Len() int { return len(h) }
+func (h rcHeap) Less(i, j int) bool {
+	if h[i].cost != h[j].cost {
+		return h[i].cost < h[j].cost
+	}
+	return h[i].resource < h[j].resource
+}
+func (h rcHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *rcHeap) Push(x interface{}) { *h = append(*h, x.(rcItem)) }
+func (h *rcHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
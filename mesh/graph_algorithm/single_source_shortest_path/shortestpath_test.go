@@ -9,12 +9,17 @@ package SingleSourceShortestPath
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
 	"testing"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct"
 
 	. "github.com/meshcross/algorithm-3rd/mesh/common"
 	. "github.com/meshcross/algorithm-3rd/mesh/graph_algorithm/graph_struct/graph_vertex"
+	. "github.com/meshcross/algorithm-3rd/mesh/queue_algorithm"
 )
 
 /**
@@ -157,3 +162,1257 @@ func TestBellmanFord(t *testing.T) {
 // 	fmt.Println(fmt.Sprintf("a-EXPECT_EQ(%d,%d)", _1v_graph.Vertexes[0].GetKey(), 0), err)
 
 // }
+
+/**
+ * @description: 随机生成的稀疏图，每个结点有degree条出边，权重属于[1,100]，使用邻接表表示
+ */
+func sparseGraph(num, degree int, seed int64) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(seed))
+	graph := NewGraph(-1, num, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < num; i++ {
+		graph.AddVertex(0)
+	}
+	for i := 0; i < num; i++ {
+		graph.AddEdge(NewTuple(i, (i+1)%num, 1+r.Intn(100))) //保证连通
+		for j := 1; j < degree; j++ {
+			graph.AddEdge(NewTuple(i, r.Intn(num), 1+r.Intn(100)))
+		}
+	}
+	return graph
+}
+
+/**
+ * @description:不同优先队列的Dijkstra结果与MinQueue一致
+ */
+func TestDijkstraQueue(t *testing.T) {
+	creators := []PriorityQueueCreator{NewBinaryHeapQueue, NewFibonacciQueue, NewPairingHeapQueue, NewLazyHeapQueue,
+		NewDialQueue(100), NewRadixHeapQueue}
+	for seed := int64(1); seed <= 5; seed++ {
+		graph := sparseGraph(300, 4, seed)
+		NewDijkstra().ShortestPath(graph, 0)
+		expect := make([]int, graph.N())
+		for i := range expect {
+			expect[i] = graph.Vertexes[i].GetKey()
+		}
+		for _, creator := range creators {
+			NewDijkstra(creator).ShortestPath(graph, 0)
+			for i := range expect {
+				vertex := graph.Vertexes[i]
+				EXPECT_EQ(vertex.GetKey(), expect[i], t)
+				if parent := vertex.GetParent(); parent != nil { //最短路径不唯一，只检查前驱结点的合法性
+					weight, _ := graph.Weight(parent.GetID(), i)
+					EXPECT_EQ(parent.GetKey()+weight, vertex.GetKey(), t)
+				}
+			}
+		}
+	}
+}
+
+const BENCHMARK_NUM = 10000 //基准测试中稀疏图的结点数
+
+func benchmarkDijkstra(b *testing.B, dijkstra *Dijkstra) {
+	graph := sparseGraph(BENCHMARK_NUM, 4, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstra.ShortestPath(graph, 0)
+	}
+}
+
+func BenchmarkDijkstraMinQueue(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra())
+}
+
+func BenchmarkDijkstraBinaryHeap(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewBinaryHeapQueue))
+}
+
+func BenchmarkDijkstraFibonacci(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewFibonacciQueue))
+}
+
+func BenchmarkDijkstraPairingHeap(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewPairingHeapQueue))
+}
+
+func BenchmarkDijkstraLazyHeap(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewLazyHeapQueue))
+}
+
+func BenchmarkDijkstraDial(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewDialQueue(100)))
+}
+
+func BenchmarkDijkstraRadixHeap(b *testing.B) {
+	benchmarkDijkstra(b, NewDijkstra(NewRadixHeapQueue))
+}
+
+/**
+ * @description:单调队列：关键字相同的元素、减小关键字以及弹出之后重新插入
+ */
+func TestMonotoneQueue(t *testing.T) {
+	for _, creator := range []PriorityQueueCreator{NewDialQueue(10), NewRadixHeapQueue} {
+		q := creator(6)
+		q.Push(0, 7)
+		q.Push(1, 3)
+		q.Push(2, 9)
+		q.Push(3, 3)
+		q.Push(2, 4) //减小关键字
+		q.Push(0, 8) //关键字没有减小，忽略
+		EXPECT_EQ(q.Len(), 4, t)
+		keys := []int{}
+		for _, expect := range [][2]int{{-1, 3}, {-1, 3}, {2, 4}} {
+			id, key := q.Pop()
+			if expect[0] >= 0 {
+				EXPECT_EQ(id, expect[0], t)
+			}
+			keys = append(keys, key)
+		}
+		EXPECT_EQ(fmt.Sprint(keys), "[3 3 4]", t)
+		q.Push(4, 4)
+		q.Push(1, 12) //弹出之后重新插入
+		for _, expect := range [][2]int{{4, 4}, {0, 7}, {1, 12}, {-1, 0}} {
+			id, key := q.Pop()
+			EXPECT_EQ(id, expect[0], t)
+			EXPECT_EQ(key, expect[1], t)
+		}
+		EXPECT_EQ(q.Len(), 0, t)
+	}
+}
+
+/**
+ * @description:Dial队列的C小于边的最大权重时扩大桶数组，Dijkstra算法的结果仍然正确
+ */
+func TestDialQueueSmallC(t *testing.T) {
+	graph := NewGraph(-1, 4, func(key, id int) IVertex { return NewVertex(key, id) }, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 4; i++ {
+		graph.AddVertex(0)
+	}
+	for _, edge := range [][3]int{{0, 1, 1}, {0, 2, 4}, {1, 2, 1}, {2, 3, 1}} {
+		graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+	}
+	for _, max_weight := range []int{0, 1, 2, 4} {
+		EXPECT_EQ(NewDijkstra(NewDialQueue(max_weight)).ShortestPath(graph, 0), nil, t)
+		keys := []int{}
+		for _, vertex := range graph.Vertexes {
+			keys = append(keys, vertex.GetKey())
+		}
+		EXPECT_EQ(keys, []int{0, 1, 2, 3}, t)
+	}
+
+	q := NewDialQueue(2)(3)
+	q.Push(0, 5)
+	q.Push(1, 1)
+	q.Push(2, 3)
+	for _, expect := range [][2]int{{1, 1}, {2, 3}, {0, 5}} {
+		id, key := q.Pop()
+		EXPECT_EQ(id, expect[0], t)
+		EXPECT_EQ(key, expect[1], t)
+	}
+
+	defer func() {
+		EXPECT_EQ(recover() != nil, true, t)
+	}()
+	NewDialQueue(-1) //C为负值时panic，recover之后检查
+}
+
+/**
+ * @description:A*搜索，分别在网格图和Graph上与Dijkstra算法比较
+ */
+func TestAStar(t *testing.T) {
+	//**********  网格图：中间有一堵墙，只在最上方留一个缺口  ***************
+	grid := NewGridGraph(50, 40)
+	for y := 1; y < grid.Height; y++ {
+		grid.SetBlocked(25, y, true)
+	}
+	grid.SetCost(10, 10, 5)
+	source, target := grid.ID(5, 30), grid.ID(45, 30)
+	astar := NewAStar()
+	result, err := astar.Search(grid, source, target, grid.ManhattanHeuristic(target))
+	EXPECT_EQ(err, nil, t)
+	dijkstra, _ := astar.Search(grid, source, target, nil)
+	EXPECT_EQ(result.Cost, dijkstra.Cost, t)
+	EXPECT_EQ(result.Cost, 40+2*30, t) //绕过墙的缺口
+	EXPECT_EQ(result.Expanded < dijkstra.Expanded, true, t)
+	EXPECT_EQ(result.Path[0], source, t)
+	EXPECT_EQ(result.Path[len(result.Path)-1], target, t)
+	cost := 0
+	for _, id := range result.Path[1:] {
+		x, y := grid.XY(id)
+		EXPECT_EQ(grid.IsBlocked(x, y), false, t)
+		cost++
+	}
+	EXPECT_EQ(cost, result.Cost, t)
+
+	grid.SetBlocked(25, 0, true) //堵住缺口
+	_, err = astar.Search(grid, source, target, grid.ManhattanHeuristic(target))
+	EXPECT_EQ(err != nil, true, t)
+
+	//**********  平面上的随机点，边的权重为向上取整的欧氏距离，启发函数为向下取整的欧氏距离  ***************
+	NUM := 400
+	r := rand.New(rand.NewSource(3))
+	xs, ys := make([]float64, NUM), make([]float64, NUM)
+	for i := 0; i < NUM; i++ {
+		xs[i], ys[i] = r.Float64()*1000, r.Float64()*1000
+	}
+	distance := func(i, j int) float64 {
+		return math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+	}
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < NUM; i++ {
+		graph.AddVertex(0)
+	}
+	for i := 0; i < NUM; i++ {
+		for j := 0; j < NUM; j++ {
+			if i != j && distance(i, j) < 120 {
+				graph.AddEdge(NewTuple(i, j, int(math.Ceil(distance(i, j)))))
+			}
+		}
+	}
+	NewDijkstra(NewBinaryHeapQueue).ShortestPath(graph, 0)
+	for target := 1; target < NUM; target += 37 {
+		heuristic := func(id int) int {
+			return int(distance(id, target))
+		}
+		result, err := astar.SearchGraph(graph, 0, target, heuristic)
+		if Is_Unlimit(graph.Vertexes[target].GetKey()) {
+			EXPECT_EQ(err != nil, true, t)
+			continue
+		}
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(result.Cost, graph.Vertexes[target].GetKey(), t)
+		cost := 0
+		for i := 1; i < len(result.Path); i++ {
+			weight, _ := graph.Weight(result.Path[i-1], result.Path[i])
+			cost += weight
+		}
+		EXPECT_EQ(cost, result.Cost, t)
+	}
+}
+
+/**
+ * @description:点到点最短路径，与整棵最短路径树的结果比较
+ */
+func TestShortestPathTo(t *testing.T) {
+	graph := sparseGraph(300, 3, 7)
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	_1e_graph := NewGraph(-1, 2, creator) //只有一条边1-->0，从0出发不可达1
+	_1e_graph.AddVertex(0)
+	_1e_graph.AddVertex(0)
+	_1e_graph.AddEdge(NewTuple(1, 0, 1))
+	for _, creator := range []PriorityQueueCreator{nil, NewPairingHeapQueue} {
+		dijkstra := NewDijkstra(creator)
+		for target := 0; target < 300; target += 13 {
+			NewDijkstra().ShortestPath(graph, 5)
+			expect := graph.Vertexes[target].GetKey()
+
+			cost, path, err := dijkstra.ShortestPathTo(graph, 5, target)
+			EXPECT_EQ(err, nil, t)
+			EXPECT_EQ(cost, expect, t)
+			EXPECT_EQ(path[0], 5, t)
+			EXPECT_EQ(path[len(path)-1], target, t)
+			sum := 0
+			for i := 1; i < len(path); i++ {
+				weight, _ := graph.Weight(path[i-1], path[i])
+				sum += weight
+			}
+			EXPECT_EQ(sum, cost, t)
+
+			cost, path, err = NewBellmanFordShortestPath().ShortestPathTo(graph, 5, target)
+			EXPECT_EQ(err, nil, t)
+			EXPECT_EQ(cost, expect, t)
+			EXPECT_EQ(path[len(path)-1], target, t)
+		}
+
+		_, _, err := dijkstra.ShortestPathTo(_1e_graph, 0, 1)
+		unreachable, ok := err.(*UnreachableError)
+		EXPECT_EQ(ok, true, t)
+		EXPECT_EQ(*unreachable, UnreachableError{Source: 0, Target: 1}, t)
+		_, _, err = NewBellmanFordShortestPath().ShortestPathTo(_1e_graph, 0, 1)
+		_, ok = err.(*UnreachableError)
+		EXPECT_EQ(ok, true, t)
+	}
+}
+
+/**
+ * @description:枚举source_id到target_id的所有简单路径的权重，按照从小到大排序
+ */
+func simplePathCosts(graph *Graph, source_id, target_id int) []int {
+	costs := []int{}
+	visited := make([]bool, graph.N())
+	var dfs func(u, cost int)
+	dfs = func(u, cost int) {
+		if u == target_id {
+			costs = append(costs, cost)
+			return
+		}
+		visited[u] = true
+		edges, _ := graph.VertexEdgeTuples(u)
+		for _, edge := range edges {
+			if !visited[edge.Second] {
+				dfs(edge.Second, cost+edge.Third)
+			}
+		}
+		visited[u] = false
+	}
+	dfs(source_id, 0)
+	sort.Ints(costs)
+	return costs
+}
+
+/**
+ * @description:前k条最短路径，与暴力枚举的结果比较
+ */
+func TestKShortestPath(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(11))
+	K := 30
+	for round := 0; round < 20; round++ {
+		NUM := 8
+		graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		dag := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < NUM; i++ {
+			graph.AddVertex(0)
+			dag.AddVertex(0)
+		}
+		for i := 0; i < NUM; i++ {
+			for j := 0; j < NUM; j++ {
+				if i != j && r.Intn(3) == 0 {
+					w := r.Intn(10)
+					graph.AddEdge(NewTuple(i, j, w))
+					if i < j {
+						dag.AddEdge(NewTuple(i, j, w))
+					}
+				}
+			}
+		}
+
+		expect := simplePathCosts(graph, 0, NUM-1)
+		paths, err := NewKShortestPath(K_SHORTEST_YEN).Paths(graph, 0, NUM-1, K)
+		if len(expect) == 0 {
+			_, ok := err.(*UnreachableError)
+			EXPECT_EQ(ok, true, t)
+			continue
+		}
+		if len(expect) > K {
+			expect = expect[:K]
+		}
+		EXPECT_EQ(len(paths), len(expect), t)
+		seen := map[string]bool{}
+		for i, path := range paths {
+			EXPECT_EQ(path.Cost, expect[i], t)
+			EXPECT_EQ(path.Path[0], 0, t)
+			EXPECT_EQ(path.Path[len(path.Path)-1], NUM-1, t)
+			sum := 0
+			visited := map[int]bool{path.Path[0]: true}
+			for p := 1; p < len(path.Path); p++ {
+				weight, _ := graph.Weight(path.Path[p-1], path.Path[p])
+				sum += weight
+				EXPECT_EQ(visited[path.Path[p]], false, t) //无环
+				visited[path.Path[p]] = true
+			}
+			EXPECT_EQ(sum, path.Cost, t)
+			EXPECT_EQ(seen[fmt.Sprint(path.Path)], false, t)
+			seen[fmt.Sprint(path.Path)] = true
+		}
+
+		//有向无环图中所有路径都是简单路径，两种算法的结果相同
+		expect = simplePathCosts(dag, 0, NUM-1)
+		if len(expect) > K {
+			expect = expect[:K]
+		}
+		for _, mode := range []KShortestPathMode{K_SHORTEST_YEN, K_SHORTEST_EPPSTEIN} {
+			paths, _ := NewKShortestPath(mode).Paths(dag, 0, NUM-1, K)
+			EXPECT_EQ(len(paths), len(expect), t)
+			for i := 0; i < len(paths) && i < len(expect); i++ {
+				EXPECT_EQ(paths[i].Cost, expect[i], t)
+			}
+		}
+	}
+
+	//**********  有环的图：0-->1(1)，1-->2(1)，2-->1(1)，Eppstein风格的算法会绕环  ***************
+	cycle := NewGraph(-1, 3, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 3; i++ {
+		cycle.AddVertex(0)
+	}
+	cycle.AddEdge(NewTuple(0, 1, 1))
+	cycle.AddEdge(NewTuple(1, 2, 1))
+	cycle.AddEdge(NewTuple(2, 1, 1))
+	paths, _ := NewKShortestPath(K_SHORTEST_EPPSTEIN).Paths(cycle, 0, 2, 3)
+	EXPECT_EQ(len(paths), 3, t)
+	EXPECT_EQ(*paths[0], WeightedPath{Cost: 2, Path: []int{0, 1, 2}}, t)
+	EXPECT_EQ(*paths[1], WeightedPath{Cost: 4, Path: []int{0, 1, 2, 1, 2}}, t)
+	EXPECT_EQ(*paths[2], WeightedPath{Cost: 6, Path: []int{0, 1, 2, 1, 2, 1, 2}}, t)
+	paths, _ = NewKShortestPath(K_SHORTEST_YEN).Paths(cycle, 0, 2, 3)
+	EXPECT_EQ(len(paths), 1, t)
+
+	//**********  无效权重为Unlimit()的矩阵图：Paths不能修改输入的图  ***************
+	edgeValues := func(graph *Graph) []Tuple {
+		values := []Tuple{}
+		for _, edge := range graph.EdgeTuples() {
+			values = append(values, *edge)
+		}
+		return values
+	}
+	for _, mode := range []KShortestPathMode{K_SHORTEST_YEN, K_SHORTEST_EPPSTEIN} {
+		matrix := NewGraph(Unlimit(), 4, creator)
+		for i := 0; i < 4; i++ {
+			matrix.AddVertex(0)
+		}
+		matrix.AddEdges([]*Tuple{NewTuple(0, 1, 1), NewTuple(1, 3, 1), NewTuple(0, 2, 2), NewTuple(2, 3, 2)})
+		before := edgeValues(matrix)
+		paths, err := NewKShortestPath(mode).Paths(matrix, 0, 3, 3)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(len(paths), 2, t)
+		EXPECT_EQ(*paths[1], WeightedPath{Cost: 4, Path: []int{0, 2, 3}}, t)
+		EXPECT_EQ(edgeValues(matrix), before, t)
+		for _, vertex := range matrix.Vertexes {
+			EXPECT_EQ(vertex.GetKey(), 0, t)
+			EXPECT_EQ(vertex.GetParent() == nil, true, t)
+		}
+	}
+}
+
+/**
+ * @description:权重为负值的环路：货币套利。汇率r转换为权重-round(1e6*ln(r))，乘积大于1的兑换环路即为权重为负值的环路
+ */
+func TestNegativeCycle(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//0:USD 1:EUR 2:GBP 3:JPY 4:CNY
+	rates := [][3]float64{
+		{0, 1, 0.92}, {1, 0, 1.08}, {1, 2, 0.86}, {2, 0, 1.27}, {0, 3, 150}, {3, 0, 0.0066}, {0, 4, 7.2}, {4, 1, 0.127},
+	}
+	graph := NewGraph(-1, 5, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 5; i++ {
+		graph.AddVertex(0)
+	}
+	for _, rate := range rates {
+		graph.AddEdge(NewTuple(int(rate[0]), int(rate[1]), -int(math.Round(1e6*math.Log(rate[2])))))
+	}
+
+	ok, err := NewBellmanFordShortestPath().ShortestPath(graph, 0)
+	EXPECT_EQ(ok, false, t)
+	cycle, is_cycle := err.(*NegativeCycleError)
+	EXPECT_EQ(is_cycle, true, t)
+	EXPECT_EQ(cycle.Weight < 0, true, t)
+	product, weight := 1.0, 0
+	for i := range cycle.Cycle {
+		from, to := cycle.Cycle[i], cycle.Cycle[(i+1)%len(cycle.Cycle)]
+		w, _ := graph.Weight(from, to)
+		weight += w
+		for _, rate := range rates {
+			if int(rate[0]) == from && int(rate[1]) == to {
+				product *= rate[2]
+			}
+		}
+	}
+	EXPECT_EQ(weight, cycle.Weight, t)
+	EXPECT_EQ(product > 1, true, t)
+
+	_, _, err = NewBellmanFordShortestPath().ShortestPathTo(graph, 0, 2)
+	_, is_cycle = err.(*NegativeCycleError)
+	EXPECT_EQ(is_cycle, true, t)
+
+	//**********  权重为负值的自环  ***************
+	loop := NewGraph(-1, 2, creator, GRAPH_REPRESENTION_ADJ)
+	loop.AddVertex(0)
+	loop.AddVertex(0)
+	loop.AddEdge(NewTuple(0, 1, 1))
+	loop.AddEdge(NewTuple(1, 1, -1))
+	_, err = NewBellmanFordShortestPath().ShortestPath(loop, 0)
+	EXPECT_EQ(*err.(*NegativeCycleError), NegativeCycleError{Cycle: []int{1}, Weight: -1}, t)
+
+	//**********  不可达的负权重环路不影响结果  ***************
+	loop = NewGraph(-1, 3, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 3; i++ {
+		loop.AddVertex(0)
+	}
+	loop.AddEdge(NewTuple(1, 2, -3))
+	loop.AddEdge(NewTuple(2, 1, 1))
+	ok, err = NewBellmanFordShortestPath().ShortestPath(loop, 0)
+	EXPECT_EQ(ok, true, t)
+	EXPECT_EQ(err, nil, t)
+}
+
+/**
+ * @description:类似道路网络的图：width*height的网格，相邻结点之间是权重相同的双向边，另外在相近的结点之间随机添加一些单向边
+ */
+func roadGraph(width, height int, seed int64) *Graph {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	r := rand.New(rand.NewSource(seed))
+	num := width * height
+	graph := NewGraph(-1, num, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < num; i++ {
+		graph.AddVertex(0)
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			id := y*width + x
+			if x+1 < width && r.Intn(10) > 0 {
+				w := 1 + r.Intn(20)
+				graph.AddEdge(NewTuple(id, id+1, w))
+				graph.AddEdge(NewTuple(id+1, id, w))
+			}
+			if y+1 < height && r.Intn(10) > 0 {
+				w := 1 + r.Intn(20)
+				graph.AddEdge(NewTuple(id, id+width, w))
+				graph.AddEdge(NewTuple(id+width, id, w))
+			}
+		}
+	}
+	for i := 0; i < num/10; i++ {
+		x, y := r.Intn(width), r.Intn(height)
+		nx, ny := x+r.Intn(7)-3, y+r.Intn(7)-3
+		if nx < 0 || nx >= width || ny < 0 || ny >= height {
+			continue
+		}
+		u, v := y*width+x, ny*width+nx
+		if has, _ := graph.HasEdge(u, v); u != v && !has {
+			graph.AddEdge(NewTuple(u, v, 10+r.Intn(50)))
+		}
+	}
+	return graph
+}
+
+/**
+ * @description:收缩层次，与Dijkstra算法的结果比较
+ */
+func TestContractionHierarchy(t *testing.T) {
+	graph := roadGraph(30, 30, 4)
+	ch := NewContractionHierarchy()
+	EXPECT_EQ(ch.Preprocess(graph), nil, t)
+
+	r := rand.New(rand.NewSource(5))
+	dijkstra := NewDijkstra(NewBinaryHeapQueue)
+	for i := 0; i < 300; i++ {
+		s, target := r.Intn(graph.N()), r.Intn(graph.N())
+		expect, _, expect_err := dijkstra.ShortestPathTo(graph, s, target)
+		cost, path, err := ch.Query(s, target)
+		if expect_err != nil {
+			EXPECT_EQ(err, expect_err, t)
+			continue
+		}
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(cost, expect, t)
+		EXPECT_EQ(path[0], s, t)
+		EXPECT_EQ(path[len(path)-1], target, t)
+		sum := 0
+		for p := 1; p < len(path); p++ {
+			weight, err := graph.Weight(path[p-1], path[p])
+			EXPECT_EQ(err, nil, t)
+			sum += weight
+		}
+		EXPECT_EQ(sum, cost, t)
+	}
+
+	//**********  不可达  ***************
+	EXPECT_EQ(ch.Preprocess(sparseGraph(1, 1, 1)), nil, t)
+	_, _, err := NewContractionHierarchy().Query(0, 0)
+	EXPECT_EQ(err != nil, true, t)
+	_1e_graph := NewGraph(-1, 2, func(key, id int) IVertex { return NewVertex(key, id) })
+	_1e_graph.AddVertex(0)
+	_1e_graph.AddVertex(0)
+	_1e_graph.AddEdge(NewTuple(1, 0, 1))
+	ch.Preprocess(_1e_graph)
+	_, _, err = ch.Query(0, 1)
+	EXPECT_EQ(err, error(&UnreachableError{Source: 0, Target: 1}), t)
+	cost, path, _ := ch.Query(1, 0)
+	EXPECT_EQ(cost, 1, t)
+	EXPECT_EQ(path, []int{1, 0}, t)
+
+	//**********  重新预处理更大的图：池中结点数不同的查询状态被丢弃  ***************
+	ch.pool.Put(newCHQueryState(2))
+	EXPECT_EQ(ch.Preprocess(graph), nil, t)
+	for i := 0; i < 10; i++ {
+		expect, _, _ := dijkstra.ShortestPathTo(graph, 0, graph.N()-1-i)
+		cost, _, err = ch.Query(0, graph.N()-1-i)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(cost, expect, t)
+	}
+}
+
+func BenchmarkContractionHierarchyQuery(b *testing.B) {
+	graph := roadGraph(100, 100, 1)
+	ch := NewContractionHierarchy()
+	ch.Preprocess(graph)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch.Query(r.Intn(graph.N()), r.Intn(graph.N()))
+	}
+}
+
+func BenchmarkContractionHierarchyDijkstra(b *testing.B) {
+	graph := roadGraph(100, 100, 1)
+	dijkstra := NewDijkstra(NewBinaryHeapQueue)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstra.ShortestPathTo(graph, r.Intn(graph.N()), r.Intn(graph.N()))
+	}
+}
+
+/**
+ * @description:差分约束系统，算法导论图24-8的例子以及一个不可行的系统
+ */
+func TestDifferenceConstraints(t *testing.T) {
+	system := NewDifferenceConstraints()
+	//x1-x2<=0, x1-x5<=-1, x2-x5<=1, x3-x1<=5, x4-x1<=4, x4-x3<=-1, x5-x3<=-3, x5-x4<=-3，下标从0开始
+	constraints := []DifferenceConstraint{
+		{J: 0, I: 1, B: 0}, {J: 0, I: 4, B: -1}, {J: 1, I: 4, B: 1}, {J: 2, I: 0, B: 5},
+		{J: 3, I: 0, B: 4}, {J: 3, I: 2, B: -1}, {J: 4, I: 2, B: -3}, {J: 4, I: 3, B: -3},
+	}
+	for _, c := range constraints {
+		system.AddConstraint(c.J, c.I, c.B)
+	}
+	system.AddConstraint(0, 1, 10) //更宽松的重复约束不起作用
+	x, err := system.Solve()
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(fmt.Sprint(x), fmt.Sprint([]int{-5, -3, 0, -1, -4}), t)
+	for _, c := range constraints {
+		EXPECT_EQ(x[c.J]-x[c.I] <= c.B, true, t)
+	}
+	EXPECT_EQ(system.AddConstraint(-1, 0, 0) != nil, true, t)
+
+	//**********  按名字添加变量：任务调度  ***************
+	tasks := NewDifferenceConstraints()
+	tasks.AddNamedConstraint("start", "build", -10) //build至少在start之前10个单位开始
+	tasks.AddNamedConstraint("build", "deploy", -5)
+	tasks.AddNamedConstraint("deploy", "start", 20) //deploy最多在start之后20个单位
+	values, err := tasks.SolveNamed()
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(values["start"]-values["build"] <= -10, true, t)
+	EXPECT_EQ(values["build"]-values["deploy"] <= -5, true, t)
+	EXPECT_EQ(values["deploy"]-values["start"] <= 20, true, t)
+
+	//**********  不可行的系统  ***************
+	tasks.AddNamedConstraint("deploy", "start", 14)
+	_, err = tasks.SolveNamed()
+	cycle, is_cycle := err.(*NegativeCycleError)
+	EXPECT_EQ(is_cycle, true, t)
+	EXPECT_EQ(cycle.Weight, -1, t)
+	infeasible := tasks.CycleConstraints(cycle)
+	EXPECT_EQ(len(infeasible), 3, t)
+	sum := 0
+	formats := []string{}
+	for _, c := range infeasible {
+		sum += c.B
+		formats = append(formats, tasks.Format(c))
+	}
+	EXPECT_EQ(sum, cycle.Weight, t)
+	sort.Strings(formats)
+	EXPECT_EQ(fmt.Sprint(formats), "[build - deploy <= -5 deploy - start <= 14 start - build <= -10]", t)
+}
+
+/**
+ * @description:关键路径：一个小型项目的任务调度，结点的key为任务的持续时间
+ */
+func TestCriticalPath(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewDFSVertex(key, id)
+	}
+	//0:设计(3) 1:前端(4) 2:后端(6) 3:测试(2) 4:文档(1) 5:发布(1)，结点6为空
+	durations := []int{3, 4, 6, 2, 1, 1}
+	graph := NewGraph(-1, 7, creator, GRAPH_REPRESENTION_ADJ)
+	for i, d := range durations {
+		graph.AddVertex(d, i)
+	}
+	for _, edge := range [][3]int{{0, 1, 0}, {0, 2, 0}, {1, 3, 0}, {2, 3, 1}, {0, 4, 0}, {3, 5, 0}, {4, 5, 0}} {
+		graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+	}
+
+	report, err := NewCriticalPath().Schedule(graph)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(report.Makespan, 13, t)
+	EXPECT_EQ(fmt.Sprint(report.Path), "[0 2 3 5]", t)
+	EXPECT_EQ(report.Tasks[6] == nil, true, t)
+	expects := []TaskSchedule{
+		{ID: 0, Duration: 3, EarliestStart: 0, EarliestFinish: 3, LatestStart: 0, LatestFinish: 3, Slack: 0, Critical: true},
+		{ID: 1, Duration: 4, EarliestStart: 3, EarliestFinish: 7, LatestStart: 6, LatestFinish: 10, Slack: 3},
+		{ID: 2, Duration: 6, EarliestStart: 3, EarliestFinish: 9, LatestStart: 3, LatestFinish: 9, Slack: 0, Critical: true},
+		{ID: 3, Duration: 2, EarliestStart: 10, EarliestFinish: 12, LatestStart: 10, LatestFinish: 12, Slack: 0, Critical: true},
+		{ID: 4, Duration: 1, EarliestStart: 3, EarliestFinish: 4, LatestStart: 11, LatestFinish: 12, Slack: 8},
+		{ID: 5, Duration: 1, EarliestStart: 12, EarliestFinish: 13, LatestStart: 12, LatestFinish: 13, Slack: 0, Critical: true},
+	}
+	for i, expect := range expects {
+		EXPECT_EQ(*report.Tasks[i], expect, t)
+	}
+	EXPECT_EQ(strings.Count(report.String(), "\n"), 8, t)
+
+	//**********  存在环路  ***************
+	graph.AddEdge(NewTuple(5, 0, 0))
+	_, err = NewCriticalPath().Schedule(graph)
+	EXPECT_EQ(err != nil, true, t)
+
+	//**********  结点类型不正确  ***************
+	_, err = NewCriticalPath().Schedule(sparseGraph(3, 1, 1))
+	EXPECT_EQ(err != nil, true, t)
+}
+
+/**
+ * @description:瓶颈路径：有向图上的最宽路径和最小最大路径
+ */
+func TestBottleneckPath(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	graph := NewGraph(-1, 6, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 6; i++ {
+		graph.AddVertex(0)
+	}
+	//0-->1-->3的容量为5，0-->2-->3的容量为3，0-->3的容量为2；结点5不可达
+	for _, edge := range [][3]int{{0, 1, 7}, {1, 3, 5}, {0, 2, 3}, {2, 3, 9}, {0, 3, 2}, {3, 4, 6}, {4, 0, 1}, {5, 0, 10}} {
+		graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+	}
+
+	widest := NewBottleneckPath(BOTTLENECK_WIDEST)
+	value, path, err := widest.ShortestPathTo(graph, 0, 3)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(value, 5, t)
+	EXPECT_EQ(fmt.Sprint(path), "[0 1 3]", t)
+	tree, _ := widest.ShortestPath(graph, 0)
+	value, _ = tree.Value(4)
+	EXPECT_EQ(value, 5, t)
+	value, _ = tree.Value(0)
+	EXPECT_EQ(value, Unlimit(), t)
+	EXPECT_EQ(tree.Reachable(5), false, t)
+	_, err = tree.Value(5)
+	EXPECT_EQ(*err.(*UnreachableError), UnreachableError{Source: 0, Target: 5}, t)
+	EXPECT_EQ(tree.Path(5) == nil, true, t)
+
+	minimax := NewBottleneckPath(BOTTLENECK_MINIMAX, NewPairingHeapQueue)
+	value, path, err = minimax.ShortestPathTo(graph, 0, 4)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(value, 6, t)
+	EXPECT_EQ(fmt.Sprint(path), "[0 3 4]", t)
+	_, _, err = minimax.ShortestPathTo(graph, 0, 5)
+	_, is_unreachable := err.(*UnreachableError)
+	EXPECT_EQ(is_unreachable, true, t)
+
+	//**********  与枚举所有简单路径的结果比较  ***************
+	for seed := int64(1); seed <= 5; seed++ {
+		graph := sparseGraph(8, 2, seed)
+		for _, mode := range []BottleneckMode{BOTTLENECK_WIDEST, BOTTLENECK_MINIMAX} {
+			tree, _ := NewBottleneckPath(mode).ShortestPath(graph, 0)
+			for target := 1; target < 8; target++ {
+				expect, found := mode.Identity(), false
+				var visit func(u, value int, visited []bool)
+				visit = func(u, value int, visited []bool) {
+					if u == target {
+						if !found || mode.Better(value, expect) {
+							expect, found = value, true
+						}
+						return
+					}
+					visited[u] = true
+					edges, _ := graph.VertexEdgeTuples(u)
+					for _, edge := range edges {
+						if !visited[edge.Second] {
+							visit(edge.Second, mode.Combine(value, edge.Third), visited)
+						}
+					}
+					visited[u] = false
+				}
+				visit(0, mode.Identity(), make([]bool, 8))
+				EXPECT_EQ(tree.Reachable(target), found, t)
+				if found {
+					value, _ := tree.Value(target)
+					EXPECT_EQ(value, expect, t)
+					path := tree.Path(target)
+					along := mode.Identity()
+					for i := 0; i+1 < len(path); i++ {
+						w, _ := graph.Weight(path[i], path[i+1])
+						along = mode.Combine(along, w)
+					}
+					EXPECT_EQ(along, expect, t)
+				}
+			}
+		}
+	}
+}
+
+/**
+ * @description:动态单源最短路径：随机地插入、删除边以及修改边的权重，每次更新后与重新执行Dijkstra算法的结果比较
+ */
+func TestDynamicShortestPath(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		NUM := 60
+		graph := sparseGraph(NUM, 2, seed)
+		r := rand.New(rand.NewSource(seed))
+		dynamic, err := NewDynamicShortestPath(graph, 0)
+		EXPECT_EQ(err, nil, t)
+		dist := func(graph *Graph) []int {
+			NewDijkstra(NewBinaryHeapQueue).ShortestPath(graph, 0)
+			result := make([]int, graph.N())
+			for i := range result {
+				result[i] = graph.Vertexes[i].GetKey()
+			}
+			return result
+		}
+		before := dist(graph)
+		for step := 0; step < 300; step++ {
+			var changed []int
+			u, v := r.Intn(NUM), r.Intn(NUM)
+			if has, _ := graph.HasEdge(u, v); has {
+				if r.Intn(2) == 0 {
+					changed, err = dynamic.DeleteEdge(u, v)
+				} else {
+					changed, err = dynamic.UpdateEdge(u, v, r.Intn(100))
+				}
+			} else {
+				changed, err = dynamic.InsertEdge(u, v, 1+r.Intn(100))
+			}
+			EXPECT_EQ(err, nil, t)
+
+			after := dist(graph)
+			expect := []int{}
+			for i := range after {
+				if after[i] != before[i] {
+					expect = append(expect, i)
+				}
+				d, err := dynamic.Dist(i)
+				if Is_Unlimit(after[i]) {
+					_, is_unreachable := err.(*UnreachableError)
+					EXPECT_EQ(is_unreachable, true, t)
+					EXPECT_EQ(dynamic.Path(i) == nil, true, t)
+					continue
+				}
+				EXPECT_EQ(d, after[i], t)
+				path := dynamic.Path(i)
+				weight := 0
+				for k := 0; k+1 < len(path); k++ {
+					w, _ := graph.Weight(path[k], path[k+1])
+					weight += w
+				}
+				EXPECT_EQ(path[0], 0, t)
+				EXPECT_EQ(weight, after[i], t)
+			}
+			EXPECT_EQ(fmt.Sprint(changed), fmt.Sprint(expect), t)
+			before = after
+		}
+	}
+
+	//**********  参数检查  ***************
+	graph := sparseGraph(3, 1, 1)
+	dynamic, _ := NewDynamicShortestPath(graph, 0)
+	_, err := dynamic.InsertEdge(0, 1, 5) //边已存在
+	EXPECT_EQ(err != nil, true, t)
+	_, err = dynamic.InsertEdge(1, 0, -1)
+	EXPECT_EQ(err != nil, true, t)
+	_, err = dynamic.DeleteEdge(1, 0)
+	EXPECT_EQ(err != nil, true, t)
+	changed, _ := dynamic.DeleteEdge(0, 1)
+	EXPECT_EQ(fmt.Sprint(changed), "[1 2]", t)
+
+	//**********  矩阵表示的图，无效权重为0：权重0不能用于插入或修改边，否则边会被静默删除  ***************
+	matrix := NewGraph(0, 3, func(key, id int) IVertex {
+		return NewVertex(key, id)
+	})
+	for i := 0; i < 3; i++ {
+		matrix.AddVertex(0)
+	}
+	matrix.AddEdges([]*Tuple{NewTuple(0, 1, 5), NewTuple(1, 2, 5)})
+	dynamic, err = NewDynamicShortestPath(matrix, 0)
+	EXPECT_EQ(err, nil, t)
+	_, err = dynamic.UpdateEdge(0, 1, 0)
+	EXPECT_EQ(err != nil, true, t)
+	has, _ := matrix.HasEdge(0, 1)
+	EXPECT_EQ(has, true, t)
+	d, _ := dynamic.Dist(1)
+	EXPECT_EQ(d, 5, t)
+	_, err = dynamic.InsertEdge(0, 2, 0)
+	EXPECT_EQ(err != nil, true, t)
+	changed, err = dynamic.UpdateEdge(0, 1, 2)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(fmt.Sprint(changed), "[1 2]", t)
+	changed, err = dynamic.InsertEdge(0, 2, 3)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(fmt.Sprint(changed), "[2]", t)
+	changed, err = dynamic.UpdateEdge(0, 1, 9)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(fmt.Sprint(changed), "[1]", t)
+	d, _ = dynamic.Dist(2)
+	EXPECT_EQ(d, 3, t)
+}
+
+/**
+ * @description:int边界附近的权重：正无穷加上负的权重、很大的权重相加都不能得到错误的"有限"值
+ */
+func TestUnlimitOverflow(t *testing.T) {
+	EXPECT_EQ(AddDistance(Unlimit(), -INT_MAX/4), Unlimit(), t)
+	EXPECT_EQ(AddDistance(1, INT_MAX), Unlimit(), t)
+	EXPECT_EQ(AddDistance(INT_MAX/4, INT_MAX/4), Unlimit(), t)
+	EXPECT_EQ(AddDistance(-INT_MAX/4, -INT_MAX/4), -Unlimit(), t)
+	EXPECT_EQ(AddDistance(-INT_MAX, -INT_MAX), -Unlimit(), t)
+	EXPECT_EQ(AddDistance(INT_MAX/8, -INT_MAX/4), INT_MAX/8-INT_MAX/4, t)
+
+	//0-->1-->4的权重为1+INT_MAX，0-->5-->6的权重约为INT_MAX/4；2从0不可达，2-->3的权重为-INT_MAX/4
+	edges := [][3]int{{0, 1, 1}, {1, 4, INT_MAX}, {0, 5, INT_MAX / 8}, {5, 6, INT_MAX / 8}, {2, 3, -INT_MAX / 4}}
+	expect := []int{0, 1, Unlimit(), Unlimit(), Unlimit(), INT_MAX / 8, INT_MAX / 8 * 2}
+	build := func(creator VertexCreatorFunc, edges [][3]int) *Graph {
+		graph := NewGraph(-1, 7, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < 7; i++ {
+			graph.AddVertex(0)
+		}
+		for _, edge := range edges {
+			graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+		}
+		return graph
+	}
+	keys := func(graph *Graph) []int {
+		result := []int{}
+		for _, vertex := range graph.Vertexes {
+			result = append(result, vertex.GetKey())
+		}
+		return result
+	}
+
+	graph := build(func(key, id int) IVertex { return NewVertex(key, id) }, edges)
+	ok, err := NewBellmanFordShortestPath().ShortestPath(graph, 0)
+	EXPECT_EQ(ok, true, t)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(keys(graph), expect, t)
+
+	dag := build(func(key, id int) IVertex { return NewDFSVertex(key, id) }, edges)
+	NewDagShortestPath().ShortestPath(dag, 0)
+	EXPECT_EQ(keys(dag), expect, t)
+
+	graph = build(func(key, id int) IVertex { return NewVertex(key, id) }, edges[:4])
+	for _, creator := range []PriorityQueueCreator{nil, NewBinaryHeapQueue} {
+		NewDijkstra(creator).ShortestPath(graph, 0)
+		EXPECT_EQ(keys(graph), expect, t)
+	}
+	dynamic, _ := NewDynamicShortestPath(graph, 0)
+	changed, _ := dynamic.InsertEdge(2, 3, INT_MAX/2)
+	EXPECT_EQ(len(changed), 0, t)
+	_, err = dynamic.Dist(4)
+	EXPECT_EQ(err != nil, true, t)
+
+	//**********  0-->1-->2的权重之和饱和为正无穷，各个算法都认为2从0不可达  ***************
+	graph = build(func(key, id int) IVertex { return NewVertex(key, id) }, [][3]int{{0, 1, INT_MAX / 4}, {1, 2, INT_MAX / 4}})
+	_, _, err = NewDijkstra().ShortestPathTo(graph, 0, 2)
+	_, unreachable := err.(*UnreachableError)
+	EXPECT_EQ(unreachable, true, t)
+	_, err = NewAStar().SearchGraph(graph, 0, 2, nil)
+	_, unreachable = err.(*UnreachableError)
+	EXPECT_EQ(unreachable, true, t)
+	ch := NewContractionHierarchy()
+	EXPECT_EQ(ch.Preprocess(graph), nil, t)
+	_, _, err = ch.Query(0, 2)
+	_, unreachable = err.(*UnreachableError)
+	EXPECT_EQ(unreachable, true, t)
+	for _, mode := range []KShortestPathMode{K_SHORTEST_YEN, K_SHORTEST_EPPSTEIN} {
+		_, err = NewKShortestPath(mode).Paths(graph, 0, 2, 2)
+		_, unreachable = err.(*UnreachableError)
+		EXPECT_EQ(unreachable, true, t)
+	}
+
+	//**********  权重很小的负值环路，最短路径权重饱和之后仍然能检测到  ***************
+	graph = build(func(key, id int) IVertex { return NewVertex(key, id) }, [][3]int{{0, 1, -INT_MAX / 4}, {1, 2, -INT_MAX / 4}, {2, 1, 0}})
+	ok, err = NewBellmanFordShortestPath().ShortestPath(graph, 0)
+	EXPECT_EQ(ok, false, t)
+	cycle, is_cycle := err.(*NegativeCycleError)
+	EXPECT_EQ(is_cycle, true, t)
+	sort.Ints(cycle.Cycle)
+	EXPECT_EQ(cycle.Cycle, []int{1, 2}, t)
+}
+
+/**
+ * @description:最小平均权重环路：与枚举所有简单环路的结果比较
+ */
+func TestMinimumMeanCycle(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//两个强连通分量{0,1,2}和{3,4}，结点5有自环，结点6不在任何环路上
+	graph := NewGraph(-1, 7, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 7; i++ {
+		graph.AddVertex(0)
+	}
+	for _, edge := range [][3]int{{0, 1, 4}, {1, 2, 1}, {2, 0, 1}, {1, 0, 8}, {2, 3, 0}, {3, 4, 2}, {4, 3, 3}, {4, 5, 1}, {5, 5, 3}, {5, 6, 0}} {
+		graph.AddEdge(NewTuple(edge[0], edge[1], edge[2]))
+	}
+	cycle, err := NewMinimumMeanCycle().Solve(graph)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(cycle.Weight, 6, t) //0-->1-->2-->0的平均权重为2，3-->4-->3为2.5，5-->5为3
+	EXPECT_EQ(cycle.Length, 3, t)
+	EXPECT_EQ(cycle.Mean(), 2.0, t)
+	sort.Ints(cycle.Cycle)
+	EXPECT_EQ(cycle.Cycle, []int{0, 1, 2}, t)
+	cycles, _ := NewMinimumMeanCycle().SolveComponents(graph)
+	EXPECT_EQ(len(cycles), 3, t)
+
+	dag := NewGraph(-1, 3, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 3; i++ {
+		dag.AddVertex(0)
+	}
+	dag.AddEdge(NewTuple(0, 1, 1))
+	_, err = NewMinimumMeanCycle().Solve(dag)
+	EXPECT_EQ(err != nil, true, t)
+
+	//**********  随机图，权重可以为负值  ***************
+	NUM := 7
+	for seed := int64(1); seed <= 30; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < NUM; i++ {
+			graph.AddVertex(0)
+		}
+		for k := 0; k < 2*NUM; k++ {
+			graph.AddEdge(NewTuple(r.Intn(NUM), r.Intn(NUM), r.Intn(40)-10))
+		}
+
+		//枚举以最小结点为起点的所有简单环路
+		var expect *MeanCycle
+		var visit func(start, u, weight int, path []int)
+		visit = func(start, u, weight int, path []int) {
+			edges, _ := graph.VertexEdgeTuples(u)
+			for _, edge := range edges {
+				if edge.Second == start {
+					c := &MeanCycle{Weight: weight + edge.Third, Length: len(path)}
+					if expect == nil || c.Less(expect) {
+						expect = c
+					}
+				} else if edge.Second > start {
+					found := false
+					for _, v := range path {
+						found = found || v == edge.Second
+					}
+					if !found {
+						visit(start, edge.Second, weight+edge.Third, append(path, edge.Second))
+					}
+				}
+			}
+		}
+		for start := 0; start < NUM; start++ {
+			visit(start, start, 0, []int{start})
+		}
+
+		cycle, err := NewMinimumMeanCycle().Solve(graph)
+		if expect == nil {
+			EXPECT_EQ(err != nil, true, t)
+			continue
+		}
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(cycle.Weight*expect.Length, expect.Weight*cycle.Length, t)
+		EXPECT_EQ(len(cycle.Cycle), cycle.Length, t)
+		weight := 0
+		for i := range cycle.Cycle {
+			w, err := graph.Weight(cycle.Cycle[i], cycle.Cycle[(i+1)%len(cycle.Cycle)])
+			EXPECT_EQ(err, nil, t)
+			weight += w
+		}
+		EXPECT_EQ(weight, cycle.Weight, t)
+	}
+}
+
+/**
+ * @description:边数受限的最短路径
+ */
+func TestHopBoundedShortestPath(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//0-->3 直达权重为10；0-->1-->3 权重为6；0-->1-->2-->3 权重为3
+	graph := NewGraph(-1, 5, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 5; i++ {
+		graph.AddVertex(0, i)
+	}
+	graph.AddEdges([]*Tuple{NewTuple(0, 3, 10), NewTuple(0, 1, 1), NewTuple(1, 3, 5), NewTuple(1, 2, 1), NewTuple(2, 3, 1)})
+
+	_, err := NewHopBoundedShortestPath().ShortestPath(graph, 0, -1)
+	EXPECT_EQ(err != nil, true, t)
+	tree, err := NewHopBoundedShortestPath().ShortestPath(graph, 0, 3)
+	EXPECT_EQ(err, nil, t)
+	dist, err := tree.Dist(3)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(dist, 3, t)
+	EXPECT_EQ(tree.Path(3), []int{0, 1, 2, 3}, t)
+	dist, _ = tree.DistWithHops(3, 2)
+	EXPECT_EQ(dist, 6, t)
+	EXPECT_EQ(tree.PathWithHops(3, 2), []int{0, 1, 3}, t)
+	EXPECT_EQ(tree.PathWithHops(3, 0), []int(nil), t)
+	_, err = tree.Dist(4)
+	_, ok := err.(*UnreachableError)
+	EXPECT_EQ(ok, true, t)
+
+	pareto := tree.ParetoPaths(3)
+	EXPECT_EQ(len(pareto), 3, t)
+	for i, expect := range []ParetoPath{{10, 1, []int{0, 3}}, {6, 2, []int{0, 1, 3}}, {3, 3, []int{0, 1, 2, 3}}} {
+		EXPECT_EQ(*pareto[i], expect, t)
+	}
+
+	//************* 随机图，与枚举所有不超过k条边的路径比较  ***************
+	NUM, K := 7, 4
+	for round := 0; round < 20; round++ {
+		graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < NUM; i++ {
+			graph.AddVertex(0, i)
+		}
+		for i := 0; i < NUM; i++ {
+			for j := 0; j < NUM; j++ {
+				if i != j && rand.Intn(3) == 0 {
+					graph.AddEdge(NewTuple(i, j, rand.Intn(20)-5))
+				}
+			}
+		}
+		best := make([][]int, K+1) //best[h][v]为恰好h条边的最小权重
+		for h := range best {
+			best[h] = make([]int, NUM)
+			for v := range best[h] {
+				best[h][v] = Unlimit()
+			}
+		}
+		best[0][0] = 0
+		for h := 1; h <= K; h++ {
+			for _, edge := range graph.EdgeTuples() {
+				if !Is_Unlimit(best[h-1][edge.First]) && best[h-1][edge.First]+edge.Third < best[h][edge.Second] {
+					best[h][edge.Second] = best[h-1][edge.First] + edge.Third
+				}
+			}
+		}
+		tree, err := NewHopBoundedShortestPath().ShortestPath(graph, 0, K)
+		EXPECT_EQ(err, nil, t)
+		for v := 0; v < NUM; v++ {
+			expect := Unlimit()
+			for h := 0; h <= K; h++ {
+				if best[h][v] < expect {
+					expect = best[h][v]
+				}
+			}
+			dist, err := tree.Dist(v)
+			if Is_Unlimit(expect) {
+				EXPECT_EQ(err != nil, true, t)
+				continue
+			}
+			EXPECT_EQ(dist, expect, t)
+			path := tree.Path(v)
+			EXPECT_EQ(path[0], 0, t)
+			EXPECT_EQ(path[len(path)-1], v, t)
+			EXPECT_EQ(len(path)-1 <= K, true, t)
+			weight := 0
+			for i := 1; i < len(path); i++ {
+				w, _ := graph.Weight(path[i-1], path[i])
+				weight += w
+			}
+			EXPECT_EQ(weight, dist, t)
+			for _, p := range tree.ParetoPaths(v) {
+				EXPECT_EQ(p.Cost, best[p.Resource][v], t)
+			}
+		}
+	}
+}
+
+/**
+ * @description:资源受限的最短路径
+ */
+func TestResourceConstrainedShortestPath(t *testing.T) {
+	creator := func(key, id int) IVertex {
+		return NewVertex(key, id)
+	}
+	//费用与时间：0-->1-->3 费用2时间10；0-->2-->3 费用6时间4；0-->3 费用9时间3；0-->1-->2-->3 费用7时间12(被支配)
+	graph := NewGraph(-1, 4, creator, GRAPH_REPRESENTION_ADJ)
+	times := NewGraph(-1, 4, creator, GRAPH_REPRESENTION_ADJ)
+	for i := 0; i < 4; i++ {
+		graph.AddVertex(0, i)
+		times.AddVertex(0, i)
+	}
+	graph.AddEdges([]*Tuple{NewTuple(0, 1, 1), NewTuple(1, 3, 1), NewTuple(0, 2, 3), NewTuple(2, 3, 3), NewTuple(0, 3, 9), NewTuple(1, 2, 3)})
+	times.AddEdges([]*Tuple{NewTuple(0, 1, 5), NewTuple(1, 3, 5), NewTuple(0, 2, 2), NewTuple(2, 3, 2), NewTuple(0, 3, 3), NewTuple(1, 2, 5)})
+
+	paths, err := NewResourceConstrainedShortestPath(-1).ParetoPaths(graph, GraphResource(times), 0, 3)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(len(paths), 3, t)
+	for i, expect := range []ParetoPath{{2, 10, []int{0, 1, 3}}, {6, 4, []int{0, 2, 3}}, {9, 3, []int{0, 3}}} {
+		EXPECT_EQ(*paths[i], expect, t)
+	}
+	cost, path, err := NewResourceConstrainedShortestPath(5).ShortestPathTo(graph, GraphResource(times), 0, 3)
+	EXPECT_EQ(err, nil, t)
+	EXPECT_EQ(cost, 6, t)
+	EXPECT_EQ(path, []int{0, 2, 3}, t)
+	_, _, err = NewResourceConstrainedShortestPath(2).ShortestPathTo(graph, GraphResource(times), 0, 3)
+	_, ok := err.(*UnreachableError)
+	EXPECT_EQ(ok, true, t)
+	_, err = NewResourceConstrainedShortestPath(-1).ParetoPaths(graph, nil, 0, 3)
+	EXPECT_EQ(err != nil, true, t)
+
+	//************* 随机图，与枚举所有简单路径得到的Pareto前沿比较  ***************
+	NUM := 7
+	for round := 0; round < 20; round++ {
+		graph := NewGraph(-1, NUM, creator, GRAPH_REPRESENTION_ADJ)
+		for i := 0; i < NUM; i++ {
+			graph.AddVertex(0, i)
+		}
+		resource := NewMatrix(NUM, 0)
+		for i := 0; i < NUM; i++ {
+			for j := 0; j < NUM; j++ {
+				if i != j && rand.Intn(2) == 0 {
+					graph.AddEdge(NewTuple(i, j, rand.Intn(10)))
+					resource[i][j] = rand.Intn(10)
+				}
+			}
+		}
+		budget := rand.Intn(30) - 1
+		all := []Pair{}
+		var visit func(u, cost, used int, seen []bool)
+		visit = func(u, cost, used int, seen []bool) {
+			if u == NUM-1 {
+				all = append(all, Pair{First: cost, Second: used})
+				return
+			}
+			edges, _ := graph.VertexEdgeTuples(u)
+			for _, edge := range edges {
+				if !seen[edge.Second] {
+					seen[edge.Second] = true
+					visit(edge.Second, cost+edge.Third, used+resource[u][edge.Second], seen)
+					seen[edge.Second] = false
+				}
+			}
+		}
+		seen := make([]bool, NUM)
+		seen[0] = true
+		visit(0, 0, 0, seen)
+		expect := map[Pair]bool{}
+		for _, p := range all {
+			if budget >= 0 && p.Second > budget {
+				continue
+			}
+			dominated := false
+			for _, q := range all {
+				dominated = dominated || q.First <= p.First && q.Second <= p.Second && q != p
+			}
+			if !dominated {
+				expect[p] = true
+			}
+		}
+
+		paths, err := NewResourceConstrainedShortestPath(budget).ParetoPaths(graph, func(u, v int) int { return resource[u][v] }, 0, NUM-1)
+		EXPECT_EQ(err, nil, t)
+		EXPECT_EQ(len(paths), len(expect), t)
+		for i, p := range paths {
+			EXPECT_EQ(expect[Pair{First: p.Cost, Second: p.Resource}], true, t)
+			EXPECT_EQ(i == 0 || paths[i-1].Cost < p.Cost && paths[i-1].Resource > p.Resource, true, t)
+			cost, used := 0, 0
+			for j := 1; j < len(p.Path); j++ {
+				w, _ := graph.Weight(p.Path[j-1], p.Path[j])
+				cost += w
+				used += resource[p.Path[j-1]][p.Path[j]]
+			}
+			EXPECT_EQ(cost, p.Cost, t)
+			EXPECT_EQ(used, p.Resource, t)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * @Description: 基于斐波那契堆的优先队列
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 17:36:02
+ * @LastEditTime: 2026-10-19 17:36:02
+ * @LastEditors:
+ *
+ *
+ * 把data_struct中的FibonacciHeap适配为IndexedPriorityQueue：每个元素id对应一个FibonacciNode，
+ * 节点的关键字为fibonacciKey{key,id}。插入时不立即执行consolidate，把合并工作推迟到ExtractMin中。
+ *
+ * 理论上斐波那契堆的减小关键字是O(1)平摊的，但data_struct中的FibonacciHeap用ArrayList保存孩子节点：
+ * cut要在父节点的孩子数组中删除x并重建兄弟链表，link也要重建兄弟链表，代价都是O(degree)=O(lgn)。
+ * 因此这里减小关键字为O(lgn)平摊，ExtractMin为O(lg^2 n)平摊，Dijkstra的时间复杂度为O((E+VlgV)lgV)，
+ * 并不优于BinaryHeapQueue，而且比较关键字要经过interface{}，常数因子更大。
+ */
+package QueueAlgorithm
+
+import (
+	. "github.com/meshcross/algorithm-3rd/mesh/data_struct"
+)
+
+type fibonacciKey struct {
+	key int
+	id  int
+}
+
+// 关键字较小者返回1
+func fibonacciKeyCompare(x, y interface{}) int {
+	kx := x.(fibonacciKey)
+	ky := y.(fibonacciKey)
+	if kx.key < ky.key {
+		return 1
+	}
+	if kx.key == ky.key {
+		return 0
+	}
+	return -1
+}
+
+type FibonacciQueue struct {
+	heap  *FibonacciHeap
+	nodes []*FibonacciNode //nodes[id]为元素id对应的节点，nil表示不在队列中
+	size  int
+}
+
+func NewFibonacciQueue(n int) IndexedPriorityQueue {
+	return &FibonacciQueue{heap: NewFibonacciHeap(fibonacciKeyCompare), nodes: make([]*FibonacciNode, n)}
+}
+
+func (a *FibonacciQueue) Len() int {
+	return a.size
+}
+
+func (a *FibonacciQueue) Push(id, key int) {
+	node := a.nodes[id]
+	if node == nil {
+		node = NewFibonacciNode(fibonacciKey{key: key, id: id}, fibonacciKeyCompare)
+		a.nodes[id] = node
+		a.heap.Insert(node, false)
+		a.size++
+		return
+	}
+	if key < node.Key.(fibonacciKey).key {
+		a.heap.DecreaseKey(node, fibonacciKey{key: key, id: id})
+	}
+}
+
+func (a *FibonacciQueue) Pop() (int, int) {
+	node := a.heap.ExtractMin()
+	if node == nil {
+		return -1, 0
+	}
+	k := node.Key.(fibonacciKey)
+	a.nodes[k.id] = nil
+	a.size--
+	return k.id, k.key
+}
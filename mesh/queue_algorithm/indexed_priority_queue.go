@@ -0,0 +1,120 @@
+/*
+ * @Description: 以整数`id`为元素的最小优先队列，以及基于带位置索引的二叉堆的实现
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 17:36:02
+ * @LastEditTime: 2026-10-19 17:36:02
+ * @LastEditors:
+ *
+ *
+ * Dijkstra、Prim等图算法中，优先队列的元素是结点的`id`(取值为[0,n))，关键字是整数。MinQueue以interface{}为元素，
+ * 执行DecreateKey之前需要用ElementIndex线性查找元素的位置，每次操作都是O(n)的。
+ *
+ * IndexedPriorityQueue把"插入"和"减小关键字"合并为一个Push操作，不同的堆结构有不同的性能：
+ *
+ *		实现                    Push(插入)    Push(减小关键字)    Pop
+ *		----------------------------------------------------------------
+ *		BinaryHeapQueue        O(lgn)        O(lgn)             O(lgn)
+ *		FibonacciQueue         O(1)          O(lgn)平摊          O(lg^2 n)平摊
+ *		PairingHeapQueue       O(1)          o(lgn)平摊          O(lgn)平摊
+ *		LazyHeapQueue          O(lgm)        O(lgm)             O(lgm)平摊
+ *
+ * 其中m为堆中(包括过期元素在内)的元素个数，不超过Push的调用次数。FibonacciQueue的孩子节点保存在数组中，
+ * 切断和链接都是O(lgn)的，所以达不到斐波那契堆理论上的O(1)平摊减小关键字，详见fibonacci_queue.go。
+ *
+ * 带位置索引的二叉堆：用数组存放二叉最小堆，另外用pos[id]记录元素id在数组中的位置，这样减小关键字时可以O(1)找到元素，
+ * 然后向上调整即可。
+ */
+package QueueAlgorithm
+
+/**
+ * @description: 以[0,n)之间的整数`id`为元素、整数为关键字的最小优先队列
+ */
+type IndexedPriorityQueue interface {
+	Push(id, key int) //插入元素id；如果id已经在队列中，则把它的关键字减小为key(key不小于当前关键字时忽略)
+	Pop() (int, int)  //弹出关键字最小的元素，返回它的`id`和关键字；队列为空时返回(-1,0)
+	Len() int         //队列中的元素个数
+}
+
+// 优先队列的创建函数，n为元素`id`的上界
+type PriorityQueueCreator func(n int) IndexedPriorityQueue
+
+type BinaryHeapQueue struct {
+	ids  []int //堆数组，存放元素id
+	keys []int //keys[id]为元素id的关键字
+	pos  []int //pos[id]为元素id在堆数组中的位置，-1表示不在队列中
+}
+
+func NewBinaryHeapQueue(n int) IndexedPriorityQueue {
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	return &BinaryHeapQueue{keys: make([]int, n), pos: pos}
+}
+
+func (a *BinaryHeapQueue) Len() int {
+	return len(a.ids)
+}
+
+func (a *BinaryHeapQueue) Push(id, key int) {
+	if a.pos[id] >= 0 {
+		if key < a.keys[id] {
+			a.keys[id] = key
+			a.up(a.pos[id])
+		}
+		return
+	}
+	a.keys[id] = key
+	a.pos[id] = len(a.ids)
+	a.ids = append(a.ids, id)
+	a.up(len(a.ids) - 1)
+}
+
+func (a *BinaryHeapQueue) Pop() (int, int) {
+	if len(a.ids) == 0 {
+		return -1, 0
+	}
+	id := a.ids[0]
+	last := len(a.ids) - 1
+	a.swap(0, last)
+	a.ids = a.ids[:last]
+	a.pos[id] = -1
+	a.down(0)
+	return id, a.keys[id]
+}
+
+func (a *BinaryHeapQueue) swap(i, j int) {
+	a.ids[i], a.ids[j] = a.ids[j], a.ids[i]
+	a.pos[a.ids[i]] = i
+	a.pos[a.ids[j]] = j
+}
+
+func (a *BinaryHeapQueue) up(i int) {
+	for i > 0 {
+		p := (i - 1) >> 1
+		if a.keys[a.ids[p]] <= a.keys[a.ids[i]] {
+			break
+		}
+		a.swap(i, p)
+		i = p
+	}
+}
+
+func (a *BinaryHeapQueue) down(i int) {
+	size := len(a.ids)
+	for {
+		smallest := i
+		l, r := (i<<1)+1, (i<<1)+2
+		if l < size && a.keys[a.ids[l]] < a.keys[a.ids[smallest]] {
+			smallest = l
+		}
+		if r < size && a.keys[a.ids[r]] < a.keys[a.ids[smallest]] {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		a.swap(i, smallest)
+		i = smallest
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * @Description: 延迟删除的二叉堆优先队列
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 17:36:02
+ * @LastEditTime: 2026-10-19 17:36:02
+ * @LastEditors:
+ *
+ *
+ * 延迟删除堆不支持真正的减小关键字操作：减小元素id的关键字时，直接把(id,新关键字)作为一个新元素插入堆中，
+ * 旧元素留在堆里成为过期元素。弹出时如果堆顶元素的关键字与id当前的关键字不一致，或者id已经弹出过，就丢弃它。
+ *
+ * 这种做法不需要位置索引，实现最简单，代价是堆的大小最多为Push的调用次数，在Dijkstra中为O(E)，
+ * 因此每次操作为O(lgE)=O(lgV)。
+ */
+package QueueAlgorithm
+
+type lazyItem struct {
+	id  int
+	key int
+}
+
+type LazyHeapQueue struct {
+	items []lazyItem //二叉最小堆，可能包含过期元素
+	keys  []int      //keys[id]为元素id当前的关键字
+	state []int8     //0表示不在队列中，1表示在队列中，2表示已经弹出
+	size  int        //有效元素个数
+}
+
+func NewLazyHeapQueue(n int) IndexedPriorityQueue {
+	return &LazyHeapQueue{keys: make([]int, n), state: make([]int8, n)}
+}
+
+func (a *LazyHeapQueue) Len() int {
+	return a.size
+}
+
+func (a *LazyHeapQueue) Push(id, key int) {
+	if a.state[id] == 1 {
+		if key >= a.keys[id] {
+			return
+		}
+	} else {
+		a.size++
+	}
+	a.state[id] = 1
+	a.keys[id] = key
+	a.items = append(a.items, lazyItem{id: id, key: key})
+	//向上调整
+	for i := len(a.items) - 1; i > 0; {
+		p := (i - 1) >> 1
+		if a.items[p].key <= a.items[i].key {
+			break
+		}
+		a.items[p], a.items[i] = a.items[i], a.items[p]
+		i = p
+	}
+}
+
+func (a *LazyHeapQueue) Pop() (int, int) {
+	for len(a.items) > 0 {
+		top := a.items[0]
+		last := len(a.items) - 1
+		a.items[0] = a.items[last]
+		a.items = a.items[:last]
+		a.down(0)
+		if a.state[top.id] == 1 && a.keys[top.id] == top.key { //丢弃过期元素
+			a.state[top.id] = 2
+			a.size--
+			return top.id, top.key
+		}
+	}
+	return -1, 0
+}
+
+func (a *LazyHeapQueue) down(i int) {
+	size := len(a.items)
+	for {
+		smallest := i
+		l, r := (i<<1)+1, (i<<1)+2
+		if l < size && a.items[l].key < a.items[smallest].key {
+			smallest = l
+		}
+		if r < size && a.items[r].key < a.items[smallest].key {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		a.items[i], a.items[smallest] = a.items[smallest], a.items[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * @Description: 基于配对堆的优先队列
+ * @Author: wangchengdg@gmail.com
+ * @Date: 2026-10-19 17:36:02
+ * @LastEditTime: 2026-10-19 17:36:02
+ * @LastEditors:
+ *
+ *
+ * 配对堆是一棵满足最小堆性质的多叉树，孩子节点用"左孩子-右兄弟"表示：
+ *
+ * - 合并(meld)：比较两个树根，关键字较大的树根成为较小树根的第一个孩子，O(1)
+ * - 插入：把新节点当作一棵树与堆合并，O(1)
+ * - 减小关键字：把以该节点为根的子树从父节点中剪下，减小关键字后再与堆合并，平摊o(lgn)
+ * - 弹出最小值：删除树根，把它的所有子树先从左到右两两合并，再从右到左依次合并，平摊O(lgn)
+ *
+ * 配对堆实现简单，常数因子小，实践中通常比斐波那契堆更快。
+ */
+package QueueAlgorithm
+
+type pairingNode struct {
+	id      int
+	key     int
+	child   *pairingNode //第一个孩子
+	sibling *pairingNode //右兄弟
+	prev    *pairingNode //左兄弟；如果是第一个孩子则为父节点
+}
+
+type PairingHeapQueue struct {
+	root  *pairingNode
+	nodes []*pairingNode //nodes[id]为元素id对应的节点，nil表示不在队列中
+	size  int
+}
+
+func NewPairingHeapQueue(n int) IndexedPriorityQueue {
+	return &PairingHeapQueue{nodes: make([]*pairingNode, n)}
+}
+
+func (a *PairingHeapQueue) Len() int {
+	return a.size
+}
+
+func (a *PairingHeapQueue) Push(id, key int) {
+	node := a.nodes[id]
+	if node == nil {
+		node = &pairingNode{id: id, key: key}
+		a.nodes[id] = node
+		a.root = a.meld(a.root, node)
+		a.size++
+		return
+	}
+	if key >= node.key {
+		return
+	}
+	node.key = key
+	if node == a.root {
+		return
+	}
+	//*********** 把以node为根的子树剪下 ****************
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev = nil
+	node.sibling = nil
+	a.root = a.meld(a.root, node)
+}
+
+func (a *PairingHeapQueue) Pop() (int, int) {
+	if a.root == nil {
+		return -1, 0
+	}
+	top := a.root
+	a.nodes[top.id] = nil
+	a.size--
+
+	//*********** 第一趟：从左到右两两合并 ****************
+	pairs := []*pairingNode{}
+	for x := top.child; x != nil; {
+		y := x.sibling
+		var next *pairingNode
+		if y != nil {
+			next = y.sibling
+		}
+		x.prev, x.sibling = nil, nil
+		if y != nil {
+			y.prev, y.sibling = nil, nil
+		}
+		pairs = append(pairs, a.meld(x, y))
+		x = next
+	}
+	//*********** 第二趟：从右到左依次合并 ****************
+	var root *pairingNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = a.meld(pairs[i], root)
+	}
+	a.root = root
+	return top.id, top.key
+}
+
+/**
+ * @description: 合并两棵树，x和y都必须是没有兄弟的树根
+ */
+func (a *PairingHeapQueue) meld(x, y *pairingNode) *pairingNode {
+	if x == nil {
+		return y
+	}
+	if y == nil {
+		return x
+	}
+	if y.key < x.key {
+		x, y = y, x
+	}
+	y.prev = x
+	y.sibling = x.child
+	if x.child != nil {
+		x.child.prev = y
+	}
+	x.child = y
+	return x
+}
@@ -34,3 +34,45 @@ func TestTask(t *testing.T) {
 		EXPECT_EQ(m, i, t)
 	}
 }
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	N := 200
+	creators := []PriorityQueueCreator{NewBinaryHeapQueue, NewFibonacciQueue, NewPairingHeapQueue, NewLazyHeapQueue}
+	for _, creator := range creators {
+		q := creator(N)
+		keys := map[int]int{} //朴素实现：当前在队列中的元素及其关键字
+		seed := 17
+		next := func(m int) int {
+			seed = (seed*1103515245 + 12345) % 2147483648
+			return (seed / 65536) % m
+		}
+		for step := 0; step < 5000; step++ {
+			if next(3) > 0 {
+				id, key := next(N), next(1000)
+				q.Push(id, key)
+				if old, ok := keys[id]; !ok || key < old {
+					keys[id] = key
+				}
+			} else if len(keys) > 0 {
+				id, key := q.Pop()
+				min := -1
+				for _, k := range keys {
+					if min < 0 || k < min {
+						min = k
+					}
+				}
+				EXPECT_EQ(key, min, t)
+				EXPECT_EQ(keys[id], key, t)
+				delete(keys, id)
+			}
+			EXPECT_EQ(q.Len(), len(keys), t)
+		}
+		for len(keys) > 0 {
+			id, key := q.Pop()
+			EXPECT_EQ(keys[id], key, t)
+			delete(keys, id)
+		}
+		id, _ := q.Pop()
+		EXPECT_EQ(id, -1, t)
+	}
+}